@@ -1,3 +1,8 @@
 module github.com/bohunn/gort-trade-model
 
-go 1.24
\ No newline at end of file
+go 1.24
+
+require (
+	github.com/shamaton/msgpack/v2 v2.4.2
+	google.golang.org/protobuf v1.35.2
+)
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ===========================================
+// HEATMAP BUILDER
+// ===========================================
+
+// RoundPriceToBucket rounds price down to the nearest multiple of
+// bucketSize; a non-positive bucketSize returns the price unchanged
+func RoundPriceToBucket(price, bucketSize float64) float64 {
+	if bucketSize <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return price
+	}
+	return math.Floor(price/bucketSize) * bucketSize
+}
+
+// bucketFractions is the default bucket width per symbol as a fraction of price
+var bucketFractions = map[Symbol]float64{
+	SymbolBTCUSDT: 0.001,
+	SymbolETHUSDT: 0.001,
+	SymbolBNBUSDT: 0.002,
+	SymbolSOLUSDT: 0.002,
+	SymbolXRPUSDT: 0.002,
+}
+
+// defaultBucketFraction applies to symbols missing from bucketFractions
+const defaultBucketFraction = 0.002
+
+// BucketSizeForSymbol returns a default heatmap bucket size scaled to the
+// price magnitude: a per-symbol fraction of currentPrice snapped to the
+// nearest 1-2-5 step, e.g. 50 for BTCUSDT at 45000. Returns 0 for a
+// non-positive price
+func BucketSizeForSymbol(symbol Symbol, currentPrice float64) float64 {
+	if currentPrice <= 0 {
+		return 0
+	}
+	fraction, ok := bucketFractions[symbol]
+	if !ok {
+		fraction = defaultBucketFraction
+	}
+
+	raw := currentPrice * fraction
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	best := 1.0
+	for _, step := range []float64{2, 5, 10} {
+		if math.Abs(raw/magnitude-step) < math.Abs(raw/magnitude-best) {
+			best = step
+		}
+	}
+	return best * magnitude
+}
+
+// HeatmapBuilder aggregates LiquidationEvents into price-bucketed HeatmapData.
+// It is not safe for concurrent use
+type HeatmapBuilder struct {
+	symbol     Symbol
+	interval   Interval
+	bucketSize float64
+
+	buckets       map[float64]*LiquidationLevel
+	exchange      Exchange
+	mixedExchange bool
+	lastTimestamp int64
+}
+
+// NewHeatmapBuilder creates a builder for symbol that snaps event prices down
+// to multiples of bucketSize; a non-positive bucketSize keeps exact prices
+func NewHeatmapBuilder(symbol Symbol, interval Interval, bucketSize float64) *HeatmapBuilder {
+	b := &HeatmapBuilder{
+		symbol:     symbol,
+		interval:   interval,
+		bucketSize: bucketSize,
+	}
+	b.Reset()
+	return b
+}
+
+// Reset discards all accumulated events so the builder can be reused
+func (b *HeatmapBuilder) Reset() {
+	b.buckets = make(map[float64]*LiquidationLevel)
+	b.exchange = ""
+	b.mixedExchange = false
+	b.lastTimestamp = 0
+}
+
+// Add buckets an event by price, splitting its notional into long or short
+// liquidations by GetLiquidationType. Events for other symbols or without a
+// price or notional are ignored
+func (b *HeatmapBuilder) Add(event LiquidationEvent) {
+	notional := event.NotionalUSD()
+	if event.Symbol != b.symbol || event.Price <= 0 || notional <= 0 {
+		return
+	}
+
+	price := RoundPriceToBucket(event.Price, b.bucketSize)
+
+	level, ok := b.buckets[price]
+	if !ok {
+		level = &LiquidationLevel{Price: price}
+		b.buckets[price] = level
+	}
+	if event.GetLiquidationType() == "LONG" {
+		level.LongLiquidations += notional
+	} else {
+		level.ShortLiquidations += notional
+	}
+	level.TotalVolume += notional
+	if event.Timestamp > level.Timestamp {
+		level.Timestamp = event.Timestamp
+	}
+	if level.OldestTimestamp == 0 || event.Timestamp < level.OldestTimestamp {
+		level.OldestTimestamp = event.Timestamp
+	}
+	if event.Timestamp > level.NewestTimestamp {
+		level.NewestTimestamp = event.Timestamp
+	}
+
+	if b.exchange == "" && !b.mixedExchange {
+		b.exchange = event.Exchange
+	} else if event.Exchange != b.exchange {
+		b.exchange = ""
+		b.mixedExchange = true
+	}
+	if event.Timestamp > b.lastTimestamp {
+		b.lastTimestamp = event.Timestamp
+	}
+}
+
+// Build returns the heatmap of the events added so far with levels sorted by
+// price and intensities normalized to the largest bucket. Exchange is set only
+// when every event came from the same exchange; Timestamp is the latest event
+// time, or now when no events were added
+func (b *HeatmapBuilder) Build(currentPrice float64) HeatmapData {
+	levels := make([]LiquidationLevel, 0, len(b.buckets))
+	var maxVolume float64
+	for _, level := range b.buckets {
+		levels = append(levels, *level)
+		maxVolume = math.Max(maxVolume, level.TotalVolume)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	for i := range levels {
+		levels[i].CalculateIntensity(maxVolume)
+	}
+
+	timestamp := b.lastTimestamp
+	if timestamp == 0 {
+		timestamp = time.Now().UnixMilli()
+	}
+
+	return HeatmapData{
+		Symbol:       b.symbol,
+		Exchange:     b.exchange,
+		Timestamp:    timestamp,
+		Interval:     b.interval,
+		CurrentPrice: currentPrice,
+		Levels:       levels,
+		Summary:      summarizeLevels(levels),
+	}
+}
+
+// heatmapBuilderState is the checkpoint format of a HeatmapBuilder
+type heatmapBuilderState struct {
+	Symbol        Symbol             `json:"symbol"`
+	Interval      Interval           `json:"interval"`
+	BucketSize    float64            `json:"bucket_size"`
+	Buckets       []LiquidationLevel `json:"buckets"`
+	Exchange      Exchange           `json:"exchange,omitempty"`
+	MixedExchange bool               `json:"mixed_exchange,omitempty"`
+	LastTimestamp int64              `json:"last_timestamp"`
+}
+
+// MarshalState serializes the builder's configuration and per-bucket volumes
+// so it can be checkpointed and resumed with RestoreHeatmapBuilder without
+// replaying the event stream
+func (b *HeatmapBuilder) MarshalState() ([]byte, error) {
+	state := heatmapBuilderState{
+		Symbol:        b.symbol,
+		Interval:      b.interval,
+		BucketSize:    b.bucketSize,
+		Buckets:       make([]LiquidationLevel, 0, len(b.buckets)),
+		Exchange:      b.exchange,
+		MixedExchange: b.mixedExchange,
+		LastTimestamp: b.lastTimestamp,
+	}
+	for _, level := range b.buckets {
+		state.Buckets = append(state.Buckets, *level)
+	}
+	sort.Slice(state.Buckets, func(i, j int) bool { return state.Buckets[i].Price < state.Buckets[j].Price })
+	return json.Marshal(state)
+}
+
+// RestoreHeatmapBuilder recreates a builder from MarshalState output
+func RestoreHeatmapBuilder(data []byte) (*HeatmapBuilder, error) {
+	var state heatmapBuilderState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid builder state: %w", err)
+	}
+	if state.Symbol == "" {
+		return nil, fmt.Errorf("invalid builder state: symbol is required")
+	}
+
+	b := NewHeatmapBuilder(state.Symbol, state.Interval, state.BucketSize)
+	for i := range state.Buckets {
+		level := state.Buckets[i]
+		b.buckets[level.Price] = &level
+	}
+	b.exchange = state.Exchange
+	b.mixedExchange = state.MixedExchange
+	b.lastTimestamp = state.LastTimestamp
+	return b, nil
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHeatmapBuilder(t *testing.T) {
+	builder := NewHeatmapBuilder(SymbolBTCUSDT, Interval1m, 100.0)
+
+	events := []LiquidationEvent{
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 1000, Side: SideSell, Price: 44010.0, Quantity: 1.0, Value: 40000.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 2000, Side: SideBuy, Price: 44090.0, Quantity: 1.0, Value: 10000.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 3000, Side: SideBuy, Price: 46050.0, Quantity: 2.0}, // no value, 92100 notional
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 4000, Side: SideLong, Price: 43950.0, Quantity: 0.5, Value: 25000.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolETHUSDT, Timestamp: 5000, Side: SideLong, Price: 2500.0, Quantity: 10, Value: 25000.0}, // ignored
+	}
+	for _, event := range events {
+		builder.Add(event)
+	}
+
+	heatmap := builder.Build(45000.0)
+	if err := heatmap.Validate(); err != nil {
+		t.Fatalf("Build() produced invalid heatmap: %v", err)
+	}
+
+	expected := []LiquidationLevel{
+		{Price: 43900.0, LongLiquidations: 25000.0, TotalVolume: 25000.0, OldestTimestamp: 4000, NewestTimestamp: 4000, Timestamp: 4000},
+		{Price: 44000.0, LongLiquidations: 40000.0, ShortLiquidations: 10000.0, TotalVolume: 50000.0, OldestTimestamp: 1000, NewestTimestamp: 2000, Timestamp: 2000},
+		{Price: 46000.0, ShortLiquidations: 92100.0, TotalVolume: 92100.0, OldestTimestamp: 3000, NewestTimestamp: 3000, Timestamp: 3000},
+	}
+	if len(heatmap.Levels) != len(expected) {
+		t.Fatalf("Build() returned %d levels, expected %d", len(heatmap.Levels), len(expected))
+	}
+	for i, want := range expected {
+		got := heatmap.Levels[i]
+		want.Intensity = want.TotalVolume / 92100.0 * 100
+		if got != want {
+			t.Errorf("level[%d] = %+v, expected %+v", i, got, want)
+		}
+	}
+
+	if heatmap.Levels[2].Intensity != 100.0 {
+		t.Errorf("max bucket Intensity = %v, expected 100", heatmap.Levels[2].Intensity)
+	}
+	if heatmap.Summary.TotalLongLiquidations != 65000.0 || heatmap.Summary.TotalShortLiquidations != 102100.0 {
+		t.Errorf("summary totals = %v/%v, expected 65000/102100",
+			heatmap.Summary.TotalLongLiquidations, heatmap.Summary.TotalShortLiquidations)
+	}
+	if heatmap.Exchange != ExchangeBinance {
+		t.Errorf("Exchange = %v, expected %v", heatmap.Exchange, ExchangeBinance)
+	}
+	if heatmap.Timestamp != 4000 {
+		t.Errorf("Timestamp = %v, expected 4000", heatmap.Timestamp)
+	}
+	if heatmap.Symbol != SymbolBTCUSDT || heatmap.Interval != Interval1m || heatmap.CurrentPrice != 45000.0 {
+		t.Errorf("heatmap header = %v/%v/%v", heatmap.Symbol, heatmap.Interval, heatmap.CurrentPrice)
+	}
+}
+
+func TestHeatmapBuilderLevelAge(t *testing.T) {
+	builder := NewHeatmapBuilder(SymbolBTCUSDT, Interval1m, 100.0)
+	for _, ts := range []int64{5000, 2000, 9000, 4000} {
+		builder.Add(LiquidationEvent{Symbol: SymbolBTCUSDT, Timestamp: ts, Side: SideSell, Price: 44050.0, Value: 1000.0})
+	}
+
+	heatmap := builder.Build(45000.0)
+	if len(heatmap.Levels) != 1 {
+		t.Fatalf("Build() returned %d levels, expected 1", len(heatmap.Levels))
+	}
+	level := heatmap.Levels[0]
+	if level.OldestTimestamp != 2000 || level.NewestTimestamp != 9000 {
+		t.Errorf("level timestamps = %v..%v, expected 2000..9000", level.OldestTimestamp, level.NewestTimestamp)
+	}
+	if age := level.AgeSpan(); age != 7*time.Second {
+		t.Errorf("AgeSpan() = %v, expected %v", age, 7*time.Second)
+	}
+
+	empty := LiquidationLevel{Price: 44000.0}
+	if age := empty.AgeSpan(); age != 0 {
+		t.Errorf("AgeSpan() without timestamps = %v, expected 0", age)
+	}
+}
+
+func TestHeatmapBuilderReset(t *testing.T) {
+	builder := NewHeatmapBuilder(SymbolBTCUSDT, Interval1m, 100.0)
+	builder.Add(LiquidationEvent{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 1000, Side: SideSell, Price: 44000.0, Value: 1000.0})
+	builder.Add(LiquidationEvent{Exchange: ExchangeOKX, Symbol: SymbolBTCUSDT, Timestamp: 2000, Side: SideSell, Price: 44000.0, Value: 1000.0})
+
+	if heatmap := builder.Build(45000.0); heatmap.Exchange != "" {
+		t.Errorf("mixed-exchange Exchange = %v, expected aggregate", heatmap.Exchange)
+	}
+
+	builder.Reset()
+	if heatmap := builder.Build(45000.0); len(heatmap.Levels) != 0 {
+		t.Errorf("Build() after Reset() returned %d levels, expected 0", len(heatmap.Levels))
+	}
+
+	builder.Add(LiquidationEvent{Exchange: ExchangeBybit, Symbol: SymbolBTCUSDT, Timestamp: 3000, Side: SideBuy, Price: 45500.0, Value: 5000.0})
+	heatmap := builder.Build(45000.0)
+	if len(heatmap.Levels) != 1 || heatmap.Levels[0].ShortLiquidations != 5000.0 {
+		t.Errorf("Build() after reuse = %+v, expected a single short level", heatmap.Levels)
+	}
+	if heatmap.Exchange != ExchangeBybit {
+		t.Errorf("Exchange after reuse = %v, expected %v", heatmap.Exchange, ExchangeBybit)
+	}
+}
+
+func TestRoundPriceToBucket(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      float64
+		bucketSize float64
+		expected   float64
+	}{
+		{name: "btc $50 bucket", price: 45037.5, bucketSize: 50.0, expected: 45000.0},
+		{name: "exactly on bucket", price: 45050.0, bucketSize: 50.0, expected: 45050.0},
+		{name: "xrp sub-cent bucket", price: 0.5123, bucketSize: 0.001, expected: 0.512},
+		{name: "zero bucket unchanged", price: 45037.5, bucketSize: 0, expected: 45037.5},
+		{name: "negative bucket unchanged", price: 45037.5, bucketSize: -50.0, expected: 45037.5},
+		{name: "negative price rounds down", price: -12.5, bucketSize: 10.0, expected: -20.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RoundPriceToBucket(tt.price, tt.bucketSize)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("RoundPriceToBucket(%v, %v) = %v, expected %v", tt.price, tt.bucketSize, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBucketSizeForSymbol(t *testing.T) {
+	tests := []struct {
+		name     string
+		symbol   Symbol
+		price    float64
+		expected float64
+	}{
+		{name: "btc at 45000", symbol: SymbolBTCUSDT, price: 45000.0, expected: 50.0},
+		{name: "eth at 2500", symbol: SymbolETHUSDT, price: 2500.0, expected: 2.0},
+		{name: "xrp at 0.5", symbol: SymbolXRPUSDT, price: 0.5, expected: 0.001},
+		{name: "unknown symbol", symbol: "DOGEUSDT", price: 0.1, expected: 0.0002},
+		{name: "invalid price", symbol: SymbolBTCUSDT, price: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := BucketSizeForSymbol(tt.symbol, tt.price)
+			if math.Abs(result-tt.expected) > tt.expected*1e-9 {
+				t.Errorf("BucketSizeForSymbol(%v, %v) = %v, expected %v", tt.symbol, tt.price, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHeatmapBuilderStateRoundTrip(t *testing.T) {
+	builder := NewHeatmapBuilder(SymbolBTCUSDT, Interval5m, 50.0)
+	for _, event := range []LiquidationEvent{
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 1000, Side: SideSell, Price: 44010.0, Value: 40000.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 2000, Side: SideBuy, Price: 46020.0, Value: 25000.0},
+	} {
+		builder.Add(event)
+	}
+
+	data, err := builder.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState() error = %v", err)
+	}
+
+	restored, err := RestoreHeatmapBuilder(data)
+	if err != nil {
+		t.Fatalf("RestoreHeatmapBuilder() error = %v", err)
+	}
+
+	if expected, got := builder.Build(45000.0), restored.Build(45000.0); !reflect.DeepEqual(got, expected) {
+		t.Errorf("restored Build() = %+v, expected %+v", got, expected)
+	}
+
+	// The restored builder keeps accumulating into the same buckets
+	next := LiquidationEvent{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 3000, Side: SideSell, Price: 44020.0, Value: 10000.0}
+	builder.Add(next)
+	restored.Add(next)
+	if expected, got := builder.Build(45000.0), restored.Build(45000.0); !reflect.DeepEqual(got, expected) {
+		t.Errorf("restored Build() after Add() = %+v, expected %+v", got, expected)
+	}
+
+	if _, err := RestoreHeatmapBuilder([]byte("not json")); err == nil {
+		t.Error("RestoreHeatmapBuilder() should reject malformed state")
+	}
+}
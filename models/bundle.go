@@ -0,0 +1,73 @@
+package models
+
+import (
+	"sort"
+)
+
+// ===========================================
+// MULTI-SYMBOL HEATMAP BUNDLES
+// ===========================================
+
+// HeatmapBundle groups the latest heatmap of several symbols
+type HeatmapBundle struct {
+	Timestamp int64         `json:"timestamp"`
+	Interval  Interval      `json:"interval,omitempty"`
+	Heatmaps  []HeatmapData `json:"heatmaps"`
+}
+
+// RankByImminentRisk returns the bundle's symbols ordered by descending
+// ImminentRiskRatio within bandPct of each heatmap's current price
+func (b *HeatmapBundle) RankByImminentRisk(bandPct float64) []Symbol {
+	type ranked struct {
+		symbol Symbol
+		ratio  float64
+	}
+
+	ranks := make([]ranked, 0, len(b.Heatmaps))
+	for i := range b.Heatmaps {
+		ranks = append(ranks, ranked{
+			symbol: b.Heatmaps[i].Symbol,
+			ratio:  b.Heatmaps[i].ImminentRiskRatio(bandPct),
+		})
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if ranks[i].ratio != ranks[j].ratio {
+			return ranks[i].ratio > ranks[j].ratio
+		}
+		return ranks[i].symbol < ranks[j].symbol
+	})
+
+	symbols := make([]Symbol, len(ranks))
+	for i, r := range ranks {
+		symbols[i] = r.symbol
+	}
+	return symbols
+}
+
+// ContagionBandPct is the distance from current price, in percent, within
+// which ContagionScore treats liquidation volume as imminent
+const ContagionBandPct = 2.0
+
+// ContagionScore returns a 0-100 market-wide stress score: the average of
+// each heatmap's ImminentRiskRatio within ContagionBandPct, weighted by its
+// share of total liquidation volume across the bundle
+func ContagionScore(bundle *HeatmapBundle) float64 {
+	if bundle == nil {
+		return 0
+	}
+
+	var weighted, total float64
+	for i := range bundle.Heatmaps {
+		var volume float64
+		for _, level := range bundle.Heatmaps[i].Levels {
+			volume += level.TotalVolume
+		}
+		weighted += bundle.Heatmaps[i].ImminentRiskRatio(ContagionBandPct) * volume
+		total += volume
+	}
+
+	if total <= 0 {
+		return 0
+	}
+	return weighted / total * 100
+}
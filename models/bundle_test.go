@@ -0,0 +1,62 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func testBundle() *HeatmapBundle {
+	heatmap := func(symbol Symbol, price, near, far float64) HeatmapData {
+		return HeatmapData{
+			Symbol:       symbol,
+			CurrentPrice: price,
+			Levels: []LiquidationLevel{
+				{Price: price * 1.005, TotalVolume: near},
+				{Price: price * 1.2, TotalVolume: far},
+			},
+		}
+	}
+
+	return &HeatmapBundle{
+		Interval: Interval1m,
+		Heatmaps: []HeatmapData{
+			heatmap(SymbolBTCUSDT, 45000.0, 2000000.0, 8000000.0), // 20% imminent
+			heatmap(SymbolETHUSDT, 2500.0, 900000.0, 100000.0),    // 90% imminent
+			heatmap(SymbolSOLUSDT, 100.0, 50000.0, 50000.0),       // 50% imminent
+		},
+	}
+}
+
+func TestRankByImminentRisk(t *testing.T) {
+	ranked := testBundle().RankByImminentRisk(1.0)
+	expected := []Symbol{SymbolETHUSDT, SymbolSOLUSDT, SymbolBTCUSDT}
+	if !reflect.DeepEqual(ranked, expected) {
+		t.Errorf("RankByImminentRisk() = %v, expected %v", ranked, expected)
+	}
+
+	empty := &HeatmapBundle{}
+	if ranked := empty.RankByImminentRisk(1.0); len(ranked) != 0 {
+		t.Errorf("RankByImminentRisk() on empty bundle = %v, expected empty", ranked)
+	}
+}
+
+func TestContagionScore(t *testing.T) {
+	// BTC holds 10M of the 11.1M total at 20% imminent; ETH and SOL are
+	// highly imminent but too small to move the score much
+	score := ContagionScore(testBundle())
+	expected := (0.2*10000000.0 + 0.9*1000000.0 + 0.5*100000.0) / 11100000.0 * 100
+	if math.Abs(score-expected) > 1e-9 {
+		t.Errorf("ContagionScore() = %v, expected %v", score, expected)
+	}
+	if score > 30 {
+		t.Errorf("ContagionScore() = %v, expected the low-risk BTC volume to dominate", score)
+	}
+
+	if score := ContagionScore(&HeatmapBundle{}); score != 0 {
+		t.Errorf("ContagionScore() on empty bundle = %v, expected 0", score)
+	}
+	if score := ContagionScore(nil); score != 0 {
+		t.Errorf("ContagionScore(nil) = %v, expected 0", score)
+	}
+}
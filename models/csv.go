@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ===========================================
+// CSV EXPORT
+// ===========================================
+
+// heatmapCSVHeader is the column layout of WriteCSV and ReadHeatmapCSV
+var heatmapCSVHeader = []string{"price", "long_liquidations", "short_liquidations", "total_volume", "intensity", "timestamp"}
+
+// WriteCSV writes a header row followed by one row per level sorted by
+// price ascending. A heatmap without levels writes only the header
+func (h *HeatmapData) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(heatmapCSVHeader); err != nil {
+		return err
+	}
+
+	formatFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	for _, level := range sortedLevels(h.Levels) {
+		record := []string{
+			formatFloat(level.Price),
+			formatFloat(level.LongLiquidations),
+			formatFloat(level.ShortLiquidations),
+			formatFloat(level.TotalVolume),
+			formatFloat(level.Intensity),
+			strconv.FormatInt(level.Timestamp, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadHeatmapCSV reads levels written by WriteCSV. Only Levels is populated;
+// the header, summary, and clusters are not part of the CSV format
+func ReadHeatmapCSV(r io.Reader) (*HeatmapData, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(heatmapCSVHeader)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	for i, column := range heatmapCSVHeader {
+		if header[i] != column {
+			return nil, fmt.Errorf("unexpected column %d: %q, expected %q", i+1, header[i], column)
+		}
+	}
+
+	heatmap := &HeatmapData{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var values [5]float64
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(record[i], 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %w", line, heatmapCSVHeader[i], err)
+			}
+		}
+		timestamp, err := strconv.ParseInt(record[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid timestamp: %w", line, err)
+		}
+
+		heatmap.Levels = append(heatmap.Levels, LiquidationLevel{
+			Price:             values[0],
+			LongLiquidations:  values[1],
+			ShortLiquidations: values[2],
+			TotalVolume:       values[3],
+			Intensity:         values[4],
+			Timestamp:         timestamp,
+		})
+	}
+	return heatmap, nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHeatmapCSVRoundTrip(t *testing.T) {
+	heatmap := &HeatmapData{
+		Symbol: SymbolBTCUSDT,
+		Levels: []LiquidationLevel{
+			{Price: 46000.5, ShortLiquidations: 25000.25, TotalVolume: 25000.25, Intensity: 25.00025, Timestamp: 1700000000123},
+			{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0, Intensity: 100.0, Timestamp: 1700000000000},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := heatmap.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "price,long_liquidations,short_liquidations,total_volume,intensity,timestamp" {
+		t.Fatalf("WriteCSV() = %q, expected header and 2 rows", buf.String())
+	}
+	if lines[1] != "44000,100000,0,100000,100,1700000000000" {
+		t.Errorf("WriteCSV() first row = %q, expected lowest price first", lines[1])
+	}
+
+	decoded, err := ReadHeatmapCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadHeatmapCSV() error = %v", err)
+	}
+	expected := []LiquidationLevel{heatmap.Levels[1], heatmap.Levels[0]}
+	if !reflect.DeepEqual(decoded.Levels, expected) {
+		t.Errorf("ReadHeatmapCSV() levels = %+v, expected %+v", decoded.Levels, expected)
+	}
+}
+
+func TestHeatmapCSVEmptyAndInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&HeatmapData{}).WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if buf.String() != "price,long_liquidations,short_liquidations,total_volume,intensity,timestamp\n" {
+		t.Errorf("WriteCSV() of empty heatmap = %q, expected only the header", buf.String())
+	}
+	decoded, err := ReadHeatmapCSV(&buf)
+	if err != nil || len(decoded.Levels) != 0 {
+		t.Errorf("ReadHeatmapCSV() of header only = %v, %v, expected no levels", decoded, err)
+	}
+
+	invalid := []string{
+		"",
+		"price,volume\n1,2\n",
+		"price,long_liquidations,short_liquidations,total_volume,intensity,timestamp\nabc,0,0,0,0,0\n",
+		"price,long_liquidations,short_liquidations,total_volume,intensity,timestamp\n1,0,0,0,0,1.5\n",
+	}
+	for _, input := range invalid {
+		if _, err := ReadHeatmapCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadHeatmapCSV(%q): expected error", input)
+		}
+	}
+}
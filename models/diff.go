@@ -0,0 +1,142 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ===========================================
+// HEATMAP DIFFS
+// ===========================================
+
+// HeatmapDiff describes how to turn one heatmap into the next, so API clients
+// can apply incremental updates instead of receiving full heatmaps
+type HeatmapDiff struct {
+	Symbol          Symbol               `json:"symbol" msgpack:"symbol"`
+	Exchange        Exchange             `json:"exchange,omitempty" msgpack:"exchange,omitempty"`
+	Timestamp       int64                `json:"timestamp" msgpack:"timestamp"`
+	Interval        Interval             `json:"interval" msgpack:"interval"`
+	CurrentPrice    float64              `json:"current_price" msgpack:"current_price"`
+	Added           []LiquidationLevel   `json:"added,omitempty" msgpack:"added,omitempty"`
+	Removed         []float64            `json:"removed,omitempty" msgpack:"removed,omitempty"` // Prices of removed levels
+	Changed         []LevelChange        `json:"changed,omitempty" msgpack:"changed,omitempty"`
+	ClustersChanged bool                 `json:"clusters_changed" msgpack:"clusters_changed"`
+	Clusters        []LiquidationCluster `json:"clusters,omitempty" msgpack:"clusters,omitempty"` // Full cluster list, set when ClustersChanged
+	SummaryChanged  bool                 `json:"summary_changed" msgpack:"summary_changed"`
+	Summary         *HeatmapSummary      `json:"summary,omitempty" msgpack:"summary,omitempty"` // Set when SummaryChanged
+}
+
+// LevelChange is a level present in both heatmaps whose fields changed
+type LevelChange struct {
+	Level          LiquidationLevel `json:"level" msgpack:"level"` // New state of the level
+	VolumeDelta    float64          `json:"volume_delta" msgpack:"volume_delta"`
+	IntensityDelta float64          `json:"intensity_delta" msgpack:"intensity_delta"`
+}
+
+// IsEmpty reports whether the diff carries no level, cluster or summary changes
+func (d *HeatmapDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		!d.ClustersChanged && !d.SummaryChanged
+}
+
+// DiffHeatmaps returns the changes from prev to curr. Levels are matched by
+// price bucket so float rounding noise doesn't register as a change; added,
+// removed and changed levels are each sorted by price. Clusters are sent in
+// full whenever they differ
+func DiffHeatmaps(prev, curr HeatmapData) HeatmapDiff {
+	diff := HeatmapDiff{
+		Symbol:       curr.Symbol,
+		Exchange:     curr.Exchange,
+		Timestamp:    curr.Timestamp,
+		Interval:     curr.Interval,
+		CurrentPrice: curr.CurrentPrice,
+	}
+
+	prevByKey := make(map[int64]LiquidationLevel, len(prev.Levels))
+	for _, level := range prev.Levels {
+		prevByKey[level.Key(defaultLevelKeySize)] = level
+	}
+
+	seen := make(map[int64]bool, len(curr.Levels))
+	for _, level := range curr.Levels {
+		key := level.Key(defaultLevelKeySize)
+		seen[key] = true
+		old, ok := prevByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, level)
+			continue
+		}
+		// Compare at the old price so bucket-level float noise isn't a change
+		compared := level
+		compared.Price = old.Price
+		if compared != old {
+			diff.Changed = append(diff.Changed, LevelChange{
+				Level:          level,
+				VolumeDelta:    level.TotalVolume - old.TotalVolume,
+				IntensityDelta: level.Intensity - old.Intensity,
+			})
+		}
+	}
+	for _, level := range prev.Levels {
+		if !seen[level.Key(defaultLevelKeySize)] {
+			diff.Removed = append(diff.Removed, level.Price)
+		}
+	}
+
+	diff.Added = sortedLevels(diff.Added)
+	sort.Float64s(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Level.Price < diff.Changed[j].Level.Price
+	})
+
+	if !reflect.DeepEqual(prev.Clusters, curr.Clusters) {
+		diff.ClustersChanged = true
+		diff.Clusters = curr.Clone().Clusters
+	}
+	if !reflect.DeepEqual(prev.Summary, curr.Summary) {
+		summary := curr.Summary
+		diff.SummaryChanged = true
+		diff.Summary = &summary
+	}
+	return diff
+}
+
+// ApplyDiff updates h in place with a diff produced by DiffHeatmaps against
+// it, leaving levels sorted by price
+func (h *HeatmapData) ApplyDiff(d HeatmapDiff) {
+	removed := make(map[int64]bool, len(d.Removed))
+	for _, price := range d.Removed {
+		level := LiquidationLevel{Price: price}
+		removed[level.Key(defaultLevelKeySize)] = true
+	}
+	changed := make(map[int64]LiquidationLevel, len(d.Changed))
+	for _, change := range d.Changed {
+		changed[change.Level.Key(defaultLevelKeySize)] = change.Level
+	}
+
+	levels := make([]LiquidationLevel, 0, len(h.Levels)+len(d.Added))
+	for _, level := range h.Levels {
+		key := level.Key(defaultLevelKeySize)
+		if removed[key] {
+			continue
+		}
+		if updated, ok := changed[key]; ok {
+			level = updated
+		}
+		levels = append(levels, level)
+	}
+	levels = append(levels, d.Added...)
+
+	h.Symbol = d.Symbol
+	h.Exchange = d.Exchange
+	h.Timestamp = d.Timestamp
+	h.Interval = d.Interval
+	h.CurrentPrice = d.CurrentPrice
+	h.Levels = sortedLevels(levels)
+	if d.ClustersChanged {
+		h.Clusters = d.Clusters
+	}
+	if d.SummaryChanged && d.Summary != nil {
+		h.Summary = *d.Summary
+	}
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffHeatmaps(t *testing.T) {
+	prev := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    1000,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 100000.0, Intensity: 100.0},
+			{Price: 44500.0, TotalVolume: 50000.0, Intensity: 50.0},
+			{Price: 46000.0, TotalVolume: 20000.0, Intensity: 20.0},
+		},
+		Summary: HeatmapSummary{TotalLongLiquidations: 170000.0},
+	}
+	x, y := 44000.1, 0.2
+	curr := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    2000,
+		CurrentPrice: 45100.0,
+		Levels: []LiquidationLevel{
+			{Price: x + y - 0.3, TotalVolume: 100000.0, Intensity: 100.0}, // unchanged, float noise on the price
+			{Price: 44500.0, TotalVolume: 80000.0, Intensity: 80.0},       // changed
+			{Price: 47000.0, TotalVolume: 30000.0, Intensity: 30.0},       // added
+		},
+		Summary: HeatmapSummary{TotalLongLiquidations: 210000.0},
+	}
+
+	diff := DiffHeatmaps(prev, curr)
+
+	if !reflect.DeepEqual(diff.Added, []LiquidationLevel{curr.Levels[2]}) {
+		t.Errorf("DiffHeatmaps() added = %+v, expected level at 47000", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []float64{46000.0}) {
+		t.Errorf("DiffHeatmaps() removed = %v, expected [46000]", diff.Removed)
+	}
+	expectedChange := []LevelChange{{Level: curr.Levels[1], VolumeDelta: 30000.0, IntensityDelta: 30.0}}
+	if !reflect.DeepEqual(diff.Changed, expectedChange) {
+		t.Errorf("DiffHeatmaps() changed = %+v, expected %+v", diff.Changed, expectedChange)
+	}
+	if !diff.SummaryChanged || diff.Summary == nil || diff.Summary.TotalLongLiquidations != 210000.0 {
+		t.Errorf("DiffHeatmaps() summary = %v, %+v, expected changed summary", diff.SummaryChanged, diff.Summary)
+	}
+
+	same := DiffHeatmaps(curr, curr)
+	if !same.IsEmpty() {
+		t.Errorf("DiffHeatmaps() of identical heatmaps = %+v, expected empty", same)
+	}
+}
+
+func TestApplyDiff(t *testing.T) {
+	prev := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Exchange:     ExchangeBinance,
+		Timestamp:    1000,
+		Interval:     Interval1m,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 100000.0},
+			{Price: 44500.0, TotalVolume: 50000.0},
+			{Price: 46000.0, TotalVolume: 20000.0},
+		},
+		Clusters: []LiquidationCluster{{Symbol: SymbolBTCUSDT, PriceRangeStart: 44000.0, PriceRangeEnd: 44500.0}},
+	}
+	curr := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Exchange:     ExchangeOKX,
+		Timestamp:    2000,
+		Interval:     Interval5m,
+		CurrentPrice: 45100.0,
+		Levels: []LiquidationLevel{
+			{Price: 43000.0, TotalVolume: 10000.0},
+			{Price: 44000.0, TotalVolume: 100000.0},
+			{Price: 44500.0, TotalVolume: 80000.0},
+		},
+		Clusters: []LiquidationCluster{
+			{Symbol: SymbolBTCUSDT, PriceRangeStart: 44000.0, PriceRangeEnd: 44500.0,
+				Levels: []LiquidationLevel{{Price: 44500.0, TotalVolume: 80000.0}}, TotalVolume: 180000.0},
+		},
+		Summary: HeatmapSummary{SignificantLevels: 1},
+	}
+
+	reconstructed := prev.Clone()
+	reconstructed.ApplyDiff(DiffHeatmaps(prev, curr))
+	if !reflect.DeepEqual(reconstructed, curr) {
+		t.Errorf("ApplyDiff() = %+v, expected %+v", reconstructed, curr)
+	}
+
+	// Only clusters differ
+	reclustered := curr.Clone()
+	reclustered.Clusters = nil
+	diff := DiffHeatmaps(curr, reclustered)
+	if diff.IsEmpty() || !diff.ClustersChanged {
+		t.Errorf("DiffHeatmaps() with changed clusters = %+v, expected ClustersChanged", diff)
+	}
+	applied := curr.Clone()
+	applied.ApplyDiff(diff)
+	if !reflect.DeepEqual(applied, reclustered) {
+		t.Errorf("ApplyDiff() = %+v, expected %+v", applied, reclustered)
+	}
+}
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StrictEnums makes UnmarshalJSON reject unknown Exchange, Side, and
+// OrderType values. Disable it to let values from newer producers pass
+// through unchanged. Empty values are always accepted
+var StrictEnums = true
+
+// ===========================================
+// ENUM PARSING AND VALIDATION
+// ===========================================
+
+// exchangeAliases maps alternative exchange names to canonical constants
+var exchangeAliases = map[string]Exchange{
+	"binance-futures": ExchangeBinance,
+	"binance_futures": ExchangeBinance,
+	"binanceusdm":     ExchangeBinance,
+	"okex":            ExchangeOKX,
+	"bybit-linear":    ExchangeBybit,
+	"gdax":            ExchangeCoinbase,
+	"coinbase-pro":    ExchangeCoinbase,
+	"coinbasepro":     ExchangeCoinbase,
+	"kraken-futures":  ExchangeKraken,
+	"krakenfutures":   ExchangeKraken,
+	"bfx":             ExchangeBitfinex,
+}
+
+// IsValid reports whether the exchange is a built-in or registered canonical
+// exchange
+func (e Exchange) IsValid() bool {
+	return isRegisteredExchange(e)
+}
+
+// ParseExchange normalizes an exchange name, accepting any casing,
+// surrounding whitespace, and known aliases such as "binance-futures"
+func ParseExchange(s string) (Exchange, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if exchange := Exchange(name); exchange.IsValid() {
+		return exchange, nil
+	}
+	if exchange, ok := exchangeAliases[name]; ok {
+		return exchange, nil
+	}
+	return "", fmt.Errorf("unknown exchange: %q", s)
+}
+
+// IsValid reports whether the symbol is a built-in or registered trading pair
+func (s Symbol) IsValid() bool {
+	return isRegisteredSymbol(s)
+}
+
+// IsValid reports whether the side is a known semantic or wire side
+func (s Side) IsValid() bool {
+	switch s {
+	case SideLong, SideShort, SideBuy, SideSell:
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize maps exchange wire sides to the canonical position side being
+// liquidated: SideSell becomes SideLong and SideBuy becomes SideShort.
+// Semantic and unknown sides are returned unchanged
+func (s Side) Normalize() Side {
+	switch s {
+	case SideSell:
+		return SideLong // Long positions get liquidated with sell orders
+	case SideBuy:
+		return SideShort // Short positions get liquidated with buy orders
+	default:
+		return s
+	}
+}
+
+// OrderSide maps a liquidated position side to the order side that closes it:
+// SideLong becomes SideSell and SideShort becomes SideBuy. Wire and unknown
+// sides are returned unchanged
+func (s Side) OrderSide() Side {
+	switch s {
+	case SideLong:
+		return SideSell
+	case SideShort:
+		return SideBuy
+	default:
+		return s
+	}
+}
+
+// IsValid reports whether the order type is known
+func (o OrderType) IsValid() bool {
+	switch o {
+	case OrderTypeLiquidation, OrderTypeADL, OrderTypeBankruptcy:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid reports whether the interval is one of the known aggregation intervals
+func (i Interval) IsValid() bool {
+	switch i {
+	case Interval1s, Interval1m, Interval5m, Interval15m, Interval1h, Interval4h, Interval1d, Interval1w:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseInterval validates a user-supplied interval string. Matching is
+// case-sensitive so typos like "5min" or "1H" are rejected instead of
+// silently falling back to 1m in GetIntervalDuration
+func ParseInterval(s string) (Interval, error) {
+	interval := Interval(strings.TrimSpace(s))
+	if !interval.IsValid() {
+		return "", fmt.Errorf("unknown interval: %q", s)
+	}
+	return interval, nil
+}
+
+// unmarshalEnumString decodes a JSON string enum value
+func unmarshalEnumString(data []byte, kind string) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("invalid %s: %w", kind, err)
+	}
+	return s, nil
+}
+
+// MarshalJSON emits the canonical lowercase exchange name
+func (e Exchange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToLower(string(e)))
+}
+
+// UnmarshalJSON parses the exchange with ParseExchange, rejecting unknown
+// names when StrictEnums is set
+func (e *Exchange) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalEnumString(data, "exchange")
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*e = ""
+		return nil
+	}
+
+	exchange, err := ParseExchange(s)
+	if err != nil {
+		if StrictEnums {
+			return err
+		}
+		exchange = Exchange(s)
+	}
+	*e = exchange
+	return nil
+}
+
+// MarshalJSON emits the side value unchanged
+func (s Side) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON decodes the side, rejecting unknown values when StrictEnums
+// is set
+func (s *Side) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnumString(data, "side")
+	if err != nil {
+		return err
+	}
+	side := Side(value)
+	if side != "" && StrictEnums && !side.IsValid() {
+		return fmt.Errorf("unknown side: %q", value)
+	}
+	*s = side
+	return nil
+}
+
+// MarshalJSON emits the order type value unchanged
+func (o OrderType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(o))
+}
+
+// UnmarshalJSON decodes the order type, rejecting unknown values when
+// StrictEnums is set
+func (o *OrderType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnumString(data, "order type")
+	if err != nil {
+		return err
+	}
+	orderType := OrderType(value)
+	if orderType != "" && StrictEnums && !orderType.IsValid() {
+		return fmt.Errorf("unknown order type: %q", value)
+	}
+	*o = orderType
+	return nil
+}
+
+// EnumCatalog returns every accepted enum value keyed by type name, for
+// serving clients their vocabulary. Exchanges and symbols include runtime
+// registrations
+func EnumCatalog() map[string][]string {
+	exchanges, symbols := registeredNames()
+	return map[string][]string{
+		"Exchange": exchanges,
+		"Symbol":   symbols,
+		"Side":     {string(SideLong), string(SideShort), string(SideBuy), string(SideSell)},
+		"Interval": {
+			string(Interval1s), string(Interval1m), string(Interval5m), string(Interval15m),
+			string(Interval1h), string(Interval4h), string(Interval1d), string(Interval1w),
+		},
+		"OrderType": {string(OrderTypeLiquidation), string(OrderTypeADL), string(OrderTypeBankruptcy)},
+	}
+}
@@ -0,0 +1,233 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseExchange(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Exchange
+		wantErr  bool
+	}{
+		{name: "canonical", input: "binance", expected: ExchangeBinance},
+		{name: "title case", input: "Binance", expected: ExchangeBinance},
+		{name: "upper case", input: "BINANCE", expected: ExchangeBinance},
+		{name: "surrounding whitespace", input: "  okx\n", expected: ExchangeOKX},
+		{name: "binance futures alias", input: "binance-futures", expected: ExchangeBinance},
+		{name: "binance usdm alias", input: "BinanceUSDM", expected: ExchangeBinance},
+		{name: "okex alias", input: "OKEx", expected: ExchangeOKX},
+		{name: "bybit alias", input: "bybit-linear", expected: ExchangeBybit},
+		{name: "gdax alias", input: "gdax", expected: ExchangeCoinbase},
+		{name: "coinbase pro alias", input: "Coinbase-Pro", expected: ExchangeCoinbase},
+		{name: "kraken futures alias", input: "kraken-futures", expected: ExchangeKraken},
+		{name: "deribit", input: "Deribit", expected: ExchangeDeribit},
+		{name: "bitfinex alias", input: "bfx", expected: ExchangeBitfinex},
+		{name: "unknown exchange", input: "foobar", wantErr: true},
+		{name: "empty string", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseExchange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExchange(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseExchange(%q) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExchangeIsValid(t *testing.T) {
+	for _, exchange := range []Exchange{
+		ExchangeBinance, ExchangeOKX, ExchangeBybit, ExchangeCoinbase,
+		ExchangeKraken, ExchangeDeribit, ExchangeBitfinex,
+	} {
+		if !exchange.IsValid() {
+			t.Errorf("%q.IsValid() = false, expected true", exchange)
+		}
+	}
+
+	for _, exchange := range []Exchange{"", "Binance", "gdax", "foobar"} {
+		if exchange.IsValid() {
+			t.Errorf("%q.IsValid() = true, expected false", exchange)
+		}
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	for _, interval := range []Interval{
+		Interval1s, Interval1m, Interval5m, Interval15m, Interval1h, Interval4h, Interval1d, Interval1w,
+	} {
+		result, err := ParseInterval(string(interval))
+		if err != nil {
+			t.Errorf("ParseInterval(%q) error = %v", interval, err)
+		}
+		if result != interval {
+			t.Errorf("ParseInterval(%q) = %v, expected %v", interval, result, interval)
+		}
+		if !interval.IsValid() {
+			t.Errorf("%q.IsValid() = false, expected true", interval)
+		}
+	}
+
+	for _, input := range []string{"", "5min", "1H", "2m", "minute", "1 m"} {
+		if result, err := ParseInterval(input); err == nil {
+			t.Errorf("ParseInterval(%q) = %v, expected error", input, result)
+		}
+		if Interval(input).IsValid() {
+			t.Errorf("%q.IsValid() = true, expected false", input)
+		}
+	}
+}
+
+func TestSideNormalize(t *testing.T) {
+	tests := []struct {
+		input    Side
+		expected Side
+	}{
+		{SideLong, SideLong},
+		{SideShort, SideShort},
+		{SideSell, SideLong},
+		{SideBuy, SideShort},
+		{"SELL", SideLong},
+		{"BUY", SideShort},
+		{"", ""},
+		{"sideways", "sideways"},
+	}
+
+	for _, tt := range tests {
+		if result := tt.input.Normalize(); result != tt.expected {
+			t.Errorf("%q.Normalize() = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestSideOrderSide(t *testing.T) {
+	tests := []struct {
+		input    Side
+		expected Side
+	}{
+		{SideLong, SideSell},
+		{SideShort, SideBuy},
+		{SideSell, SideSell},
+		{SideBuy, SideBuy},
+		{"sideways", "sideways"},
+	}
+
+	for _, tt := range tests {
+		if result := tt.input.OrderSide(); result != tt.expected {
+			t.Errorf("%q.OrderSide() = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr bool
+	}{
+		{name: "valid event", payload: `{"exchange":"binance","side":"SELL","order_type":"liquidation"}`},
+		{name: "exchange alias", payload: `{"exchange":"Binance-Futures","side":"long","order_type":"adl"}`},
+		{name: "empty enums", payload: `{"exchange":"","side":"","order_type":""}`},
+		{name: "unknown exchange", payload: `{"exchange":"foobar","side":"SELL"}`, wantErr: true},
+		{name: "unknown side", payload: `{"exchange":"okx","side":"sideways"}`, wantErr: true},
+		{name: "unknown order type", payload: `{"exchange":"okx","order_type":"market"}`, wantErr: true},
+		{name: "non-string exchange", payload: `{"exchange":42}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event LiquidationEvent
+			err := json.Unmarshal([]byte(tt.payload), &event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("json.Unmarshal(%s) error = %v, wantErr %v", tt.payload, err, tt.wantErr)
+			}
+		})
+	}
+
+	var event LiquidationEvent
+	if err := json.Unmarshal([]byte(`{"exchange":"OKEx"}`), &event); err != nil || event.Exchange != ExchangeOKX {
+		t.Errorf("json.Unmarshal() Exchange = %v (error %v), expected %v", event.Exchange, err, ExchangeOKX)
+	}
+}
+
+func TestEnumUnmarshalJSONLenient(t *testing.T) {
+	defer func(strict bool) { StrictEnums = strict }(StrictEnums)
+	StrictEnums = false
+
+	var event LiquidationEvent
+	payload := `{"exchange":"newdex","side":"sideways","order_type":"market"}`
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v, expected pass-through", err)
+	}
+	if event.Exchange != "newdex" || event.Side != "sideways" || event.OrderType != "market" {
+		t.Errorf("json.Unmarshal() = %v/%v/%v, expected unknown values to pass through",
+			event.Exchange, event.Side, event.OrderType)
+	}
+}
+
+func TestEnumMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Exchange  Exchange  `json:"exchange"`
+		Side      Side      `json:"side"`
+		OrderType OrderType `json:"order_type"`
+	}{Exchange("Binance"), SideSell, OrderTypeADL})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	expected := `{"exchange":"binance","side":"SELL","order_type":"adl"}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() = %s, expected %s", data, expected)
+	}
+}
+
+func TestEnumCatalog(t *testing.T) {
+	catalog := EnumCatalog()
+
+	expected := map[string][]string{
+		"Exchange":  {"binance", "okx", "bybit", "coinbase", "kraken", "deribit", "bitfinex"},
+		"Symbol":    {"BTCUSDT", "ETHUSDT", "BNBUSDT", "SOLUSDT", "XRPUSDT"},
+		"Side":      {"long", "short", "BUY", "SELL"},
+		"Interval":  {"1s", "1m", "5m", "15m", "1h", "4h", "1d", "1w"},
+		"OrderType": {"liquidation", "adl", "bankruptcy"},
+	}
+	for kind, values := range expected {
+		got := make(map[string]bool, len(catalog[kind]))
+		for _, value := range catalog[kind] {
+			got[value] = true
+		}
+		for _, value := range values {
+			if !got[value] {
+				t.Errorf("EnumCatalog()[%q] missing %q: %v", kind, value, catalog[kind])
+			}
+		}
+	}
+
+	for _, value := range catalog["Interval"] {
+		if !Interval(value).IsValid() {
+			t.Errorf("EnumCatalog() Interval %q is not valid", value)
+		}
+	}
+	for _, value := range catalog["Side"] {
+		if !Side(value).IsValid() {
+			t.Errorf("EnumCatalog() Side %q is not valid", value)
+		}
+	}
+
+	RegisterSymbol("AVAXUSDT")
+	t.Cleanup(func() { unregisterSymbol("AVAXUSDT") })
+	found := false
+	for _, value := range EnumCatalog()["Symbol"] {
+		found = found || value == "AVAXUSDT"
+	}
+	if !found {
+		t.Error("EnumCatalog() should include registered symbols")
+	}
+}
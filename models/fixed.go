@@ -0,0 +1,95 @@
+package models
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// ===========================================
+// FIXED-SCHEMA BINARY LAYOUT
+// ===========================================
+
+// Fixed layout sizes, all values little-endian.
+//
+// Header:
+//
+//	[0:16)  symbol, zero padded
+//	[16:24) timestamp (int64)
+//	[24:32) current price (float64)
+//	[32:40) level count (uint64)
+//
+// Each level record:
+//
+//	[0:8)   price (float64)
+//	[8:16)  long liquidations (float64)
+//	[16:24) short liquidations (float64)
+//	[24:32) intensity (float64)
+//	[32:40) timestamp (int64)
+//
+// TotalVolume is not stored; it is restored as long + short liquidations.
+const (
+	FixedSymbolSize      = 16
+	FixedHeaderSize      = FixedSymbolSize + 3*8
+	FixedLevelRecordSize = 5 * 8
+)
+
+// MarshalFixed encodes the heatmap into a fixed-size header followed by
+// fixed-size level records so clients can index into the buffer directly
+func (h *HeatmapData) MarshalFixed() ([]byte, error) {
+	if len(h.Symbol) > FixedSymbolSize {
+		return nil, fmt.Errorf("symbol %q exceeds %d bytes", h.Symbol, FixedSymbolSize)
+	}
+
+	buf := make([]byte, FixedHeaderSize+len(h.Levels)*FixedLevelRecordSize)
+	copy(buf[:FixedSymbolSize], h.Symbol)
+	binary.LittleEndian.PutUint64(buf[16:], uint64(h.Timestamp))
+	binary.LittleEndian.PutUint64(buf[24:], math.Float64bits(h.CurrentPrice))
+	binary.LittleEndian.PutUint64(buf[32:], uint64(len(h.Levels)))
+
+	for i, level := range h.Levels {
+		rec := buf[FixedHeaderSize+i*FixedLevelRecordSize:]
+		binary.LittleEndian.PutUint64(rec[0:], math.Float64bits(level.Price))
+		binary.LittleEndian.PutUint64(rec[8:], math.Float64bits(level.LongLiquidations))
+		binary.LittleEndian.PutUint64(rec[16:], math.Float64bits(level.ShortLiquidations))
+		binary.LittleEndian.PutUint64(rec[24:], math.Float64bits(level.Intensity))
+		binary.LittleEndian.PutUint64(rec[32:], uint64(level.Timestamp))
+	}
+
+	return buf, nil
+}
+
+// UnmarshalFixed decodes a buffer produced by MarshalFixed into the heatmap,
+// replacing its symbol, timestamp, current price, and levels
+func (h *HeatmapData) UnmarshalFixed(data []byte) error {
+	if len(data) < FixedHeaderSize {
+		return fmt.Errorf("fixed heatmap too short: %d bytes", len(data))
+	}
+
+	count := binary.LittleEndian.Uint64(data[32:])
+	if count > uint64((len(data)-FixedHeaderSize)/FixedLevelRecordSize) {
+		return fmt.Errorf("fixed heatmap truncated: %d levels in %d bytes", count, len(data))
+	}
+	if expected := FixedHeaderSize + int(count)*FixedLevelRecordSize; len(data) != expected {
+		return fmt.Errorf("fixed heatmap length %d, expected %d", len(data), expected)
+	}
+
+	h.Symbol = Symbol(bytes.TrimRight(data[:FixedSymbolSize], "\x00"))
+	h.Timestamp = int64(binary.LittleEndian.Uint64(data[16:]))
+	h.CurrentPrice = math.Float64frombits(binary.LittleEndian.Uint64(data[24:]))
+
+	h.Levels = make([]LiquidationLevel, count)
+	for i := range h.Levels {
+		rec := data[FixedHeaderSize+i*FixedLevelRecordSize:]
+		level := &h.Levels[i]
+		level.Price = math.Float64frombits(binary.LittleEndian.Uint64(rec[0:]))
+		level.LongLiquidations = math.Float64frombits(binary.LittleEndian.Uint64(rec[8:]))
+		level.ShortLiquidations = math.Float64frombits(binary.LittleEndian.Uint64(rec[16:]))
+		level.Intensity = math.Float64frombits(binary.LittleEndian.Uint64(rec[24:]))
+		level.Timestamp = int64(binary.LittleEndian.Uint64(rec[32:]))
+		level.TotalVolume = level.LongLiquidations + level.ShortLiquidations
+	}
+
+	return nil
+}
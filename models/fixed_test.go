@@ -0,0 +1,66 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalFixedRoundTrip(t *testing.T) {
+	heatmap := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    1234567890,
+		CurrentPrice: 45000.5,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, LongLiquidations: 100000.0, ShortLiquidations: 25000.5, TotalVolume: 125000.5, Intensity: 100.0, Timestamp: 1234567000},
+			{Price: 46000.0, ShortLiquidations: 62500.0, TotalVolume: 62500.0, Intensity: 50.0, Timestamp: 1234567500},
+		},
+	}
+
+	data, err := heatmap.MarshalFixed()
+	if err != nil {
+		t.Fatalf("MarshalFixed() error = %v", err)
+	}
+
+	if expected := FixedHeaderSize + len(heatmap.Levels)*FixedLevelRecordSize; len(data) != expected {
+		t.Errorf("MarshalFixed() length = %d, expected %d", len(data), expected)
+	}
+
+	var decoded HeatmapData
+	if err := decoded.UnmarshalFixed(data); err != nil {
+		t.Fatalf("UnmarshalFixed() error = %v", err)
+	}
+
+	if decoded.Symbol != heatmap.Symbol || decoded.Timestamp != heatmap.Timestamp ||
+		decoded.CurrentPrice != heatmap.CurrentPrice {
+		t.Errorf("UnmarshalFixed() header = %v/%v/%v, expected %v/%v/%v",
+			decoded.Symbol, decoded.Timestamp, decoded.CurrentPrice,
+			heatmap.Symbol, heatmap.Timestamp, heatmap.CurrentPrice)
+	}
+	if !reflect.DeepEqual(decoded.Levels, heatmap.Levels) {
+		t.Errorf("UnmarshalFixed() levels = %+v, expected %+v", decoded.Levels, heatmap.Levels)
+	}
+}
+
+func TestUnmarshalFixedErrors(t *testing.T) {
+	heatmap := HeatmapData{
+		Symbol: SymbolETHUSDT,
+		Levels: []LiquidationLevel{{Price: 2000.0}},
+	}
+	data, err := heatmap.MarshalFixed()
+	if err != nil {
+		t.Fatalf("MarshalFixed() error = %v", err)
+	}
+
+	var decoded HeatmapData
+	if err := decoded.UnmarshalFixed(data[:FixedHeaderSize-1]); err == nil {
+		t.Error("UnmarshalFixed() should reject a short header")
+	}
+	if err := decoded.UnmarshalFixed(data[:len(data)-1]); err == nil {
+		t.Error("UnmarshalFixed() should reject a truncated record")
+	}
+
+	long := HeatmapData{Symbol: Symbol("AVERYLONGSYMBOLNAME")}
+	if _, err := long.MarshalFixed(); err == nil {
+		t.Error("MarshalFixed() should reject a symbol longer than the fixed field")
+	}
+}
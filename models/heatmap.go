@@ -0,0 +1,1084 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ===========================================
+// HEATMAP ANALYTICS
+// ===========================================
+
+// Clone returns a deep copy of the heatmap whose Levels, Clusters (including
+// their levels) and Summary.CriticalZones can be mutated without affecting h
+func (h *HeatmapData) Clone() HeatmapData {
+	clone := *h
+	clone.Levels = slices.Clone(h.Levels)
+	clone.Clusters = slices.Clone(h.Clusters)
+	for i := range clone.Clusters {
+		clone.Clusters[i].Levels = slices.Clone(h.Clusters[i].Levels)
+	}
+	clone.Summary.CriticalZones = slices.Clone(h.Summary.CriticalZones)
+	return clone
+}
+
+// defaultLevelKeySize is the bucket size used to match levels across snapshots
+// when no explicit bucket size is available; it absorbs float rounding noise
+const defaultLevelKeySize = 1e-6
+
+// Key returns an integer bucket key for the level price so levels from
+// different snapshots match reliably despite float rounding. A non-positive
+// bucketSize falls back to defaultLevelKeySize
+func (ll *LiquidationLevel) Key(bucketSize float64) int64 {
+	if bucketSize <= 0 {
+		bucketSize = defaultLevelKeySize
+	}
+	return int64(math.Round(ll.Price / bucketSize))
+}
+
+// keyPrice returns the level price snapped to its default Key so it can be
+// used as a stable map key
+func (ll *LiquidationLevel) keyPrice() float64 {
+	return float64(ll.Key(defaultLevelKeySize)) / (1 / defaultLevelKeySize)
+}
+
+// ApproachingWall projects CurrentPrice forward by velocity (price units per
+// second) over lookAhead and returns the first significant level the
+// projected price would cross
+func (h *HeatmapData) ApproachingWall(velocity float64, lookAhead time.Duration, threshold float64) (*LiquidationLevel, bool) {
+	if h.CurrentPrice <= 0 || velocity == 0 || lookAhead <= 0 {
+		return nil, false
+	}
+
+	projected := h.CurrentPrice + velocity*lookAhead.Seconds()
+
+	var wall *LiquidationLevel
+	for i := range h.Levels {
+		level := &h.Levels[i]
+		if !level.IsSignificant(threshold) {
+			continue
+		}
+
+		if velocity > 0 {
+			if level.Price <= h.CurrentPrice || level.Price > projected {
+				continue
+			}
+			if wall == nil || level.Price < wall.Price {
+				wall = level
+			}
+		} else {
+			if level.Price >= h.CurrentPrice || level.Price < projected {
+				continue
+			}
+			if wall == nil || level.Price > wall.Price {
+				wall = level
+			}
+		}
+	}
+
+	return wall, wall != nil
+}
+
+// minDecayedIntensity is the intensity below which a decayed level is dropped
+const minDecayedIntensity = 0.01
+
+// DecayLevels blends previous level intensities into the current levels so
+// walls fade between snapshots instead of vanishing. A current level keeps the
+// larger of its own intensity and the decayed previous one; previous levels
+// absent from curr are carried over with cleared volumes and a decayed
+// intensity until it falls below minDecayedIntensity
+func DecayLevels(prev []LiquidationLevel, curr []LiquidationLevel, decay float64) []LiquidationLevel {
+	if decay < 0 {
+		decay = 0
+	}
+	if decay > 1 {
+		decay = 1
+	}
+
+	prevByKey := make(map[int64]LiquidationLevel, len(prev))
+	for _, level := range prev {
+		prevByKey[level.Key(defaultLevelKeySize)] = level
+	}
+
+	result := make([]LiquidationLevel, 0, len(curr)+len(prev))
+	seen := make(map[int64]bool, len(curr))
+	for _, level := range curr {
+		key := level.Key(defaultLevelKeySize)
+		if p, ok := prevByKey[key]; ok {
+			if decayed := p.Intensity * decay; decayed > level.Intensity {
+				level.Intensity = decayed
+			}
+		}
+		seen[key] = true
+		result = append(result, level)
+	}
+
+	for _, level := range prev {
+		key := level.Key(defaultLevelKeySize)
+		if seen[key] {
+			continue
+		}
+		decayed := level.Intensity * decay
+		if decayed < minDecayedIntensity {
+			continue
+		}
+		result = append(result, LiquidationLevel{
+			Price:     level.Price,
+			Intensity: decayed,
+			Timestamp: level.Timestamp,
+		})
+		seen[key] = true
+	}
+
+	return result
+}
+
+// minDecayedVolume is the USD volume below which a time-decayed level is dropped
+const minDecayedVolume = 1e-6
+
+// ApplyTimeDecay scales each level's volumes by 0.5^(age / halfLife), where
+// age is the time from the level's Timestamp to now in milliseconds. Levels
+// with a future or unset (zero) timestamp are left unchanged, and levels
+// decaying below minDecayedVolume are dropped. Intensities and the summary
+// are then rebuilt as ComputeSummary does at DefaultSignificanceThreshold,
+// which clears CriticalZones. A non-positive halfLife is a no-op
+func (h *HeatmapData) ApplyTimeDecay(now int64, halfLife time.Duration) {
+	if halfLife <= 0 {
+		return
+	}
+
+	kept := h.Levels[:0]
+	for _, level := range h.Levels {
+		age := time.Duration(now-level.Timestamp) * time.Millisecond
+		if level.Timestamp > 0 && age > 0 {
+			factor := math.Pow(0.5, float64(age)/float64(halfLife))
+			level.LongLiquidations *= factor
+			level.ShortLiquidations *= factor
+			level.TotalVolume *= factor
+			if level.TotalVolume < minDecayedVolume {
+				continue
+			}
+		}
+		kept = append(kept, level)
+	}
+	h.Levels = kept
+	h.normalizeIntensities()
+	h.Summary = ComputeSummary(h.Levels, DefaultSignificanceThreshold)
+}
+
+// NearestSignificantLevel returns the significant level closest to CurrentPrice
+func (h *HeatmapData) NearestSignificantLevel(threshold float64) (*LiquidationLevel, bool) {
+	var nearest *LiquidationLevel
+	var nearestDist float64
+	for i := range h.Levels {
+		level := &h.Levels[i]
+		if !level.IsSignificant(threshold) {
+			continue
+		}
+		dist := math.Abs(level.Price - h.CurrentPrice)
+		if nearest == nil || dist < nearestDist {
+			nearest = level
+			nearestDist = dist
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// FindNearestLevel returns the level whose price is closest to CurrentPrice
+func (h *HeatmapData) FindNearestLevel() (LiquidationLevel, bool) {
+	if len(h.Levels) == 0 {
+		return LiquidationLevel{}, false
+	}
+
+	nearest := h.Levels[0]
+	nearestDist := math.Abs(nearest.Price - h.CurrentPrice)
+	for _, level := range h.Levels[1:] {
+		if dist := math.Abs(level.Price - h.CurrentPrice); dist < nearestDist {
+			nearest = level
+			nearestDist = dist
+		}
+	}
+	return nearest, true
+}
+
+// LevelsWithinPercent returns every level within pct% above or below
+// CurrentPrice, sorted by ascending distance from it
+func (h *HeatmapData) LevelsWithinPercent(pct float64) []LiquidationLevel {
+	if pct <= 0 || h.CurrentPrice <= 0 {
+		return nil
+	}
+
+	band := h.CurrentPrice * pct / 100
+	var result []LiquidationLevel
+	for _, level := range h.Levels {
+		if math.Abs(level.Price-h.CurrentPrice) <= band {
+			result = append(result, level)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return math.Abs(result[i].Price-h.CurrentPrice) < math.Abs(result[j].Price-h.CurrentPrice)
+	})
+	return result
+}
+
+// AnnotateDistances sets DistancePct on every level to its signed distance
+// from CurrentPrice, (Price - CurrentPrice) / CurrentPrice * 100, so levels
+// below the price are negative. A non-positive CurrentPrice zeroes every level
+func (h *HeatmapData) AnnotateDistances() {
+	for i := range h.Levels {
+		if h.CurrentPrice <= 0 {
+			h.Levels[i].DistancePct = 0
+			continue
+		}
+		h.Levels[i].DistancePct = (h.Levels[i].Price - h.CurrentPrice) / h.CurrentPrice * 100
+	}
+}
+
+// ProximityScore returns a 0-100 risk gauge that grows as the nearest
+// significant level approaches CurrentPrice: 100 / (1 + distance%), so a wall
+// at the price scores 100 and a wall 1% away scores 50
+func (h *HeatmapData) ProximityScore(threshold float64) float64 {
+	if h.CurrentPrice <= 0 {
+		return 0
+	}
+
+	level, ok := h.NearestSignificantLevel(threshold)
+	if !ok {
+		return 0
+	}
+
+	distancePct := math.Abs(level.Price-h.CurrentPrice) / h.CurrentPrice * 100
+	return 100 / (1 + distancePct)
+}
+
+// ComputeProximityWeightedIntensity sets WeightedIntensity on every level by
+// scaling its volume with a proximity factor of 1 / (1 + distance%) from
+// CurrentPrice and normalizing the result to 0-100
+func (h *HeatmapData) ComputeProximityWeightedIntensity() {
+	if h.CurrentPrice <= 0 {
+		return
+	}
+
+	weighted := make([]float64, len(h.Levels))
+	var maxWeighted float64
+	for i := range h.Levels {
+		distancePct := math.Abs(h.Levels[i].Price-h.CurrentPrice) / h.CurrentPrice * 100
+		weighted[i] = h.Levels[i].TotalVolume / (1 + distancePct)
+		if weighted[i] > maxWeighted {
+			maxWeighted = weighted[i]
+		}
+	}
+
+	for i := range h.Levels {
+		if maxWeighted <= 0 {
+			h.Levels[i].WeightedIntensity = 0
+			continue
+		}
+		h.Levels[i].WeightedIntensity = weighted[i] / maxWeighted * 100
+	}
+}
+
+// RelativeIntensity sets RelativeIntensity on every level to its TotalVolume
+// as a percentage of referenceTotalVolume, such as a trailing average of the
+// max level volume, so scores are comparable across snapshots. Unlike
+// Intensity the result is not capped at 100; a non-positive reference
+// zeroes every level
+func (h *HeatmapData) RelativeIntensity(referenceTotalVolume float64) {
+	for i := range h.Levels {
+		if referenceTotalVolume <= 0 {
+			h.Levels[i].RelativeIntensity = 0
+			continue
+		}
+		h.Levels[i].RelativeIntensity = h.Levels[i].TotalVolume / referenceTotalVolume * 100
+	}
+}
+
+// Wall represents a run of adjacent levels dominated by the same side
+type Wall struct {
+	PriceStart float64 `json:"price_start"`
+	PriceEnd   float64 `json:"price_end"`
+	Side       string  `json:"side"` // "long" or "short"
+	Volume     float64 `json:"volume"`
+}
+
+// ExtractWalls coalesces adjacent levels with the same dominant side into
+// walls, keeping those whose combined volume exceeds minWallVolume. Levels
+// without volume break a run
+func (h *HeatmapData) ExtractWalls(minWallVolume float64) []Wall {
+	var walls []Wall
+	var current *Wall
+
+	flush := func() {
+		if current != nil && current.Volume > minWallVolume {
+			walls = append(walls, *current)
+		}
+		current = nil
+	}
+
+	for _, level := range sortedLevels(h.Levels) {
+		volume := level.LongLiquidations + level.ShortLiquidations
+		if volume <= 0 {
+			flush()
+			continue
+		}
+
+		side := "short"
+		if level.LongLiquidations >= level.ShortLiquidations {
+			side = "long"
+		}
+
+		if current != nil && current.Side != side {
+			flush()
+		}
+		if current == nil {
+			current = &Wall{PriceStart: level.Price, Side: side}
+		}
+		current.PriceEnd = level.Price
+		current.Volume += volume
+	}
+	flush()
+
+	return walls
+}
+
+// sortedLevels returns a copy of levels sorted by ascending price
+func sortedLevels(levels []LiquidationLevel) []LiquidationLevel {
+	sorted := append([]LiquidationLevel(nil), levels...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Price < sorted[j].Price
+	})
+	return sorted
+}
+
+// DefaultSignificanceThreshold is the intensity at which a level counts toward
+// Summary.SignificantLevels
+const DefaultSignificanceThreshold = 50.0
+
+// RecomputeIntensities recomputes intensities and SignificantLevels at
+// DefaultSignificanceThreshold
+func (h *HeatmapData) RecomputeIntensities() {
+	h.RecomputeIntensitiesWithThreshold(DefaultSignificanceThreshold)
+}
+
+// RecomputeIntensitiesWithThreshold normalizes every level's Intensity against
+// the largest TotalVolume in the heatmap, so levels merged from different
+// sources share one 0-100 scale, and recounts Summary.SignificantLevels at
+// significanceThreshold. All-zero volumes leave every intensity at 0
+func (h *HeatmapData) RecomputeIntensitiesWithThreshold(significanceThreshold float64) {
+	h.normalizeIntensities()
+
+	significant := 0
+	for i := range h.Levels {
+		if h.Levels[i].IsSignificant(significanceThreshold) {
+			significant++
+		}
+	}
+	h.Summary.SignificantLevels = significant
+}
+
+// normalizeIntensities recomputes every level's Intensity against the largest
+// TotalVolume in the heatmap, leaving the summary untouched
+func (h *HeatmapData) normalizeIntensities() {
+	var maxVolume float64
+	for _, level := range h.Levels {
+		if level.TotalVolume > maxVolume {
+			maxVolume = level.TotalVolume
+		}
+	}
+	for i := range h.Levels {
+		h.Levels[i].CalculateIntensity(maxVolume)
+	}
+}
+
+// Repair fixes common inconsistencies in place and returns a description of
+// every repair it performed: levels without a finite positive price are
+// dropped, other non-finite values are zeroed, duplicate prices are
+// coalesced, TotalVolume is reset to long+short, levels are sorted by price,
+// and intensities and summary totals are recomputed
+func (h *HeatmapData) Repair() []string {
+	var repairs []string
+
+	kept := h.Levels[:0]
+	for _, level := range h.Levels {
+		if math.IsNaN(level.Price) || math.IsInf(level.Price, 0) || level.Price <= 0 {
+			repairs = append(repairs, fmt.Sprintf("dropped level with invalid price %v", level.Price))
+			continue
+		}
+		kept = append(kept, level)
+	}
+	h.Levels = kept
+
+	sanitized := 0
+	sanitize := func(v *float64) {
+		if math.IsNaN(*v) || math.IsInf(*v, 0) {
+			*v = 0
+			sanitized++
+		}
+	}
+	sanitize(&h.CurrentPrice)
+	for i := range h.Levels {
+		level := &h.Levels[i]
+		sanitize(&level.LongLiquidations)
+		sanitize(&level.ShortLiquidations)
+		sanitize(&level.TotalVolume)
+		sanitize(&level.Intensity)
+		sanitize(&level.WeightedIntensity)
+		sanitize(&level.RelativeIntensity)
+		sanitize(&level.PriceLow)
+		sanitize(&level.PriceHigh)
+		sanitize(&level.OIFraction)
+		sanitize(&level.DistancePct)
+	}
+	if sanitized > 0 {
+		repairs = append(repairs, fmt.Sprintf("sanitized %d non-finite values", sanitized))
+	}
+
+	merged := make([]LiquidationLevel, 0, len(h.Levels))
+	index := make(map[int64]int, len(h.Levels))
+	for _, level := range h.Levels {
+		key := level.Key(defaultLevelKeySize)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, level)
+			continue
+		}
+		existing := &merged[i]
+		existing.LongLiquidations += level.LongLiquidations
+		existing.ShortLiquidations += level.ShortLiquidations
+		if level.Timestamp > existing.Timestamp {
+			existing.Timestamp = level.Timestamp
+		}
+		repairs = append(repairs, fmt.Sprintf("coalesced duplicate level at price %v", level.Price))
+	}
+	h.Levels = merged
+
+	for i := range h.Levels {
+		level := &h.Levels[i]
+		total := level.LongLiquidations + level.ShortLiquidations
+		if level.TotalVolume != total {
+			repairs = append(repairs, fmt.Sprintf("corrected total volume at price %v from %v to %v",
+				level.Price, level.TotalVolume, total))
+			level.TotalVolume = total
+		}
+	}
+
+	if !sort.SliceIsSorted(h.Levels, func(i, j int) bool { return h.Levels[i].Price < h.Levels[j].Price }) {
+		h.Levels = sortedLevels(h.Levels)
+		repairs = append(repairs, "sorted levels by price")
+	}
+
+	before := make([]float64, len(h.Levels))
+	for i := range h.Levels {
+		before[i] = h.Levels[i].Intensity
+	}
+	h.normalizeIntensities()
+	recomputed := 0
+	for i := range h.Levels {
+		if h.Levels[i].Intensity != before[i] {
+			recomputed++
+		}
+	}
+	if recomputed > 0 {
+		repairs = append(repairs, fmt.Sprintf("recomputed intensity for %d levels", recomputed))
+	}
+
+	summary := summarizeLevels(h.Levels)
+	summary.SignificantLevels = h.Summary.SignificantLevels
+	summary.CriticalZones = h.Summary.CriticalZones
+	if summary.TotalLongLiquidations != h.Summary.TotalLongLiquidations ||
+		summary.TotalShortLiquidations != h.Summary.TotalShortLiquidations ||
+		summary.MaxLiquidationPrice != h.Summary.MaxLiquidationPrice ||
+		summary.MaxLiquidationVolume != h.Summary.MaxLiquidationVolume ||
+		summary.WeightedAvgLongPrice != h.Summary.WeightedAvgLongPrice ||
+		summary.WeightedAvgShortPrice != h.Summary.WeightedAvgShortPrice {
+		h.Summary = summary
+		repairs = append(repairs, "recomputed summary totals")
+	}
+
+	return repairs
+}
+
+// ComputeSummary builds a HeatmapSummary from levels: long and short
+// totals, the level with the largest total volume, volume-weighted average
+// long and short prices, and the number of levels significant at
+// significanceThreshold. CriticalZones is left empty
+func ComputeSummary(levels []LiquidationLevel, significanceThreshold float64) HeatmapSummary {
+	summary := summarizeLevels(levels)
+	for i := range levels {
+		if levels[i].IsSignificant(significanceThreshold) {
+			summary.SignificantLevels++
+		}
+	}
+	return summary
+}
+
+// summarizeLevels computes the volume totals, max-volume level, and
+// volume-weighted average prices of a level set
+func summarizeLevels(levels []LiquidationLevel) HeatmapSummary {
+	var summary HeatmapSummary
+	var longWeighted, shortWeighted float64
+
+	for _, level := range levels {
+		summary.TotalLongLiquidations += level.LongLiquidations
+		summary.TotalShortLiquidations += level.ShortLiquidations
+		longWeighted += level.Price * level.LongLiquidations
+		shortWeighted += level.Price * level.ShortLiquidations
+
+		if level.TotalVolume > summary.MaxLiquidationVolume {
+			summary.MaxLiquidationVolume = level.TotalVolume
+			summary.MaxLiquidationPrice = level.Price
+		}
+	}
+
+	if summary.TotalLongLiquidations > 0 {
+		summary.WeightedAvgLongPrice = longWeighted / summary.TotalLongLiquidations
+	}
+	if summary.TotalShortLiquidations > 0 {
+		summary.WeightedAvgShortPrice = shortWeighted / summary.TotalShortLiquidations
+	}
+
+	return summary
+}
+
+// CascadeImpactPerNotional is the additional price move, in percent, caused by
+// each USD of liquidated notional in cascade simulations
+var CascadeImpactPerNotional = 1e-6 // 1% per $1M liquidated
+
+// maxCascadeIterations bounds the cascade fixed-point iteration
+const maxCascadeIterations = 100
+
+// CascadeMultiplier simulates an initial price move of initialMovePct percent
+// (negative for a drop), triggers the liquidations in its path, and feeds
+// their price impact back until the move converges. It returns the total move
+// as a multiple of the initial move, or 0 when the inputs are unusable
+func (h *HeatmapData) CascadeMultiplier(initialMovePct float64) float64 {
+	if initialMovePct == 0 || h.CurrentPrice <= 0 {
+		return 0
+	}
+	return h.simulateCascade(initialMovePct, CascadeImpactPerNotional) / initialMovePct
+}
+
+// CascadeTargetPrice estimates where a liquidation cascade settles: price
+// moves initialMovePct percent from CurrentPrice, every $1M of liquidations
+// triggered in its path moves it a further impactPerMillion percent, and the
+// process repeats until no new liquidations are reached
+func (h *HeatmapData) CascadeTargetPrice(initialMovePct float64, impactPerMillion float64) float64 {
+	if h.CurrentPrice <= 0 {
+		return 0
+	}
+	if initialMovePct == 0 {
+		return h.CurrentPrice
+	}
+	move := h.simulateCascade(initialMovePct, impactPerMillion/1e6)
+	return h.CurrentPrice * (1 + move/100)
+}
+
+// simulateCascade returns the settled total move in percent. A falling price
+// triggers long liquidations below CurrentPrice and a rising price triggers
+// short liquidations above it; each triggered USD moves price a further
+// impactPerUSD percent in the same direction
+func (h *HeatmapData) simulateCascade(initialMovePct, impactPerUSD float64) float64 {
+	direction := 1.0
+	if initialMovePct < 0 {
+		direction = -1.0
+	}
+
+	move := initialMovePct
+	for i := 0; i < maxCascadeIterations; i++ {
+		target := h.CurrentPrice * (1 + move/100)
+
+		var triggered float64
+		for _, level := range h.Levels {
+			if direction < 0 && level.Price < h.CurrentPrice && level.Price >= target {
+				triggered += level.LongLiquidations
+			} else if direction > 0 && level.Price > h.CurrentPrice && level.Price <= target {
+				triggered += level.ShortLiquidations
+			}
+		}
+
+		next := initialMovePct + direction*triggered*impactPerUSD
+		if next < -100 {
+			next = -100
+		}
+		if math.Abs(next-move) < 1e-9 {
+			return next
+		}
+		move = next
+	}
+
+	return move
+}
+
+// VolumeDensity returns a closure that linearly interpolates TotalVolume
+// between levels so callers can query arbitrary prices without snapping to
+// buckets. Prices outside the level range return 0
+func (h *HeatmapData) VolumeDensity() func(price float64) float64 {
+	levels := sortedLevels(h.Levels)
+
+	return func(price float64) float64 {
+		n := len(levels)
+		if n == 0 || price < levels[0].Price || price > levels[n-1].Price {
+			return 0
+		}
+
+		i := sort.Search(n, func(i int) bool { return levels[i].Price >= price })
+		if levels[i].Price == price || i == 0 {
+			return levels[i].TotalVolume
+		}
+
+		lo, hi := levels[i-1], levels[i]
+		frac := (price - lo.Price) / (hi.Price - lo.Price)
+		return lo.TotalVolume + frac*(hi.TotalVolume-lo.TotalVolume)
+	}
+}
+
+// WeightedMedianPrice returns the price at which cumulative liquidation volume
+// reaches half the total, walking up from the lowest price. When the split
+// falls exactly between two levels the midpoint of their prices is returned
+func (h *HeatmapData) WeightedMedianPrice() float64 {
+	return volumeQuantilePrice(sortedLevels(h.Levels), 0.5)
+}
+
+// VolumeQuantiles returns, for each quantile, the price at which cumulative
+// liquidation volume reaches that fraction of the total walking up from the
+// lowest price, using the same rules as WeightedMedianPrice. Quantiles are
+// clamped to [0, 1] and results keep the order of the input
+func (h *HeatmapData) VolumeQuantiles(quantiles []float64) []float64 {
+	sorted := sortedLevels(h.Levels)
+	prices := make([]float64, len(quantiles))
+	for i, q := range quantiles {
+		prices[i] = volumeQuantilePrice(sorted, math.Min(math.Max(q, 0), 1))
+	}
+	return prices
+}
+
+// volumeQuantilePrice returns the price at which cumulative TotalVolume across
+// price-sorted levels reaches fraction q of the total
+func volumeQuantilePrice(sorted []LiquidationLevel, q float64) float64 {
+	var total float64
+	for _, level := range sorted {
+		if level.TotalVolume > 0 {
+			total += level.TotalVolume
+		}
+	}
+	if total <= 0 {
+		return 0
+	}
+
+	target := q * total
+	var cumulative float64
+	for i, level := range sorted {
+		if level.TotalVolume <= 0 {
+			continue
+		}
+		cumulative += level.TotalVolume
+		if cumulative < target-1e-9*total {
+			continue
+		}
+		if math.Abs(cumulative-target) <= 1e-9*total {
+			for _, next := range sorted[i+1:] {
+				if next.TotalVolume > 0 {
+					return (level.Price + next.Price) / 2
+				}
+			}
+		}
+		return level.Price
+	}
+
+	return sorted[len(sorted)-1].Price
+}
+
+// AverageLevelVolume returns the mean TotalVolume across levels
+func (h *HeatmapData) AverageLevelVolume() float64 {
+	if len(h.Levels) == 0 {
+		return 0
+	}
+	var total float64
+	for _, level := range h.Levels {
+		total += level.TotalVolume
+	}
+	return total / float64(len(h.Levels))
+}
+
+// Strength returns how many times the average level volume this level holds
+func (ll *LiquidationLevel) Strength(avgLevelVolume float64) float64 {
+	if avgLevelVolume <= 0 {
+		return 0
+	}
+	return ll.TotalVolume / avgLevelVolume
+}
+
+// ImminentRiskRatio returns the fraction of total liquidation volume sitting
+// within bandPct percent of CurrentPrice
+func (h *HeatmapData) ImminentRiskRatio(bandPct float64) float64 {
+	if h.CurrentPrice <= 0 || bandPct < 0 {
+		return 0
+	}
+
+	var near, total float64
+	for _, level := range h.Levels {
+		total += level.TotalVolume
+		if math.Abs(level.Price-h.CurrentPrice)/h.CurrentPrice*100 <= bandPct {
+			near += level.TotalVolume
+		}
+	}
+
+	if total <= 0 {
+		return 0
+	}
+	return near / total
+}
+
+// PressureGradient splits the distance from CurrentPrice to the farthest
+// level into numBins equal bins and returns, for each bin from nearest to
+// farthest, long liquidation volume below price minus short liquidation
+// volume above it. Positive values mean long liquidations dominate at that
+// distance, a net downward pull; negative values mean shorts dominate, a net
+// upward pull. Nil is returned when numBins or CurrentPrice is not positive
+func (h *HeatmapData) PressureGradient(numBins int) []float64 {
+	if numBins <= 0 || h.CurrentPrice <= 0 {
+		return nil
+	}
+
+	var maxDistance float64
+	for _, level := range h.Levels {
+		maxDistance = math.Max(maxDistance, math.Abs(level.Price-h.CurrentPrice))
+	}
+
+	gradient := make([]float64, numBins)
+	if maxDistance == 0 {
+		return gradient
+	}
+	width := maxDistance / float64(numBins)
+
+	for _, level := range h.Levels {
+		bin := int(math.Abs(level.Price-h.CurrentPrice) / width)
+		if bin >= numBins {
+			bin = numBins - 1
+		}
+		if level.Price < h.CurrentPrice {
+			gradient[bin] += level.LongLiquidations
+		} else if level.Price > h.CurrentPrice {
+			gradient[bin] -= level.ShortLiquidations
+		}
+	}
+	return gradient
+}
+
+// LiquidationImbalance returns (long - short) / (long + short) liquidation
+// volume across all levels, from -1 (all short) to 1 (all long)
+func (h *HeatmapData) LiquidationImbalance() float64 {
+	var long, short float64
+	for _, level := range h.Levels {
+		long += level.LongLiquidations
+		short += level.ShortLiquidations
+	}
+	if total := long + short; total > 0 {
+		return (long - short) / total
+	}
+	return 0
+}
+
+// BiasDivergence returns short.LiquidationImbalance() minus
+// long.LiquidationImbalance(). A positive value means the short-interval
+// heatmap is more long-biased than the long-interval one. Returns 0 when
+// either heatmap is missing or the symbols differ
+func BiasDivergence(short, long *HeatmapData) float64 {
+	if short == nil || long == nil || short.Symbol != long.Symbol {
+		return 0
+	}
+	return short.LiquidationImbalance() - long.LiquidationImbalance()
+}
+
+// SupportResistance returns the prices of significant long-liquidation
+// levels below CurrentPrice (support) and significant short-liquidation
+// levels above it (resistance), each ordered nearest to price first
+func (h *HeatmapData) SupportResistance(threshold float64) (support, resistance []float64) {
+	for _, level := range sortedLevels(h.Levels) {
+		if !level.IsSignificant(threshold) {
+			continue
+		}
+		if level.Price < h.CurrentPrice && level.LongLiquidations > level.ShortLiquidations {
+			support = append([]float64{level.Price}, support...)
+		} else if level.Price > h.CurrentPrice && level.ShortLiquidations > level.LongLiquidations {
+			resistance = append(resistance, level.Price)
+		}
+	}
+	return support, resistance
+}
+
+// NoWallSpread is returned by WallSpread when either side has no wall
+const NoWallSpread = -1.0
+
+// WallSpread returns the width of the corridor between the nearest
+// significant long wall below CurrentPrice and the nearest significant short
+// wall above it, as a percentage of CurrentPrice. Walls are the levels
+// reported by SupportResistance. NoWallSpread is returned when a side has no
+// wall or CurrentPrice is not positive
+func (h *HeatmapData) WallSpread(threshold float64) float64 {
+	if h.CurrentPrice <= 0 {
+		return NoWallSpread
+	}
+	support, resistance := h.SupportResistance(threshold)
+	if len(support) == 0 || len(resistance) == 0 {
+		return NoWallSpread
+	}
+	return (resistance[0] - support[0]) / h.CurrentPrice * 100
+}
+
+// MagnetPrice returns the volume-weighted average price of all levels, the
+// heatmap's liquidation center of gravity
+func (h *HeatmapData) MagnetPrice() float64 {
+	var weighted, total float64
+	for _, level := range h.Levels {
+		weighted += level.Price * level.TotalVolume
+		total += level.TotalVolume
+	}
+	if total <= 0 {
+		return 0
+	}
+	return weighted / total
+}
+
+// MagnetDrift returns the change in MagnetPrice from prev to curr as a
+// percentage of curr.CurrentPrice; positive means the liquidation center of
+// mass is rising. Returns 0 when either heatmap is missing, the symbols
+// differ, or either has no volume
+func MagnetDrift(prev, curr *HeatmapData) float64 {
+	if prev == nil || curr == nil || prev.Symbol != curr.Symbol || curr.CurrentPrice <= 0 {
+		return 0
+	}
+	before, after := prev.MagnetPrice(), curr.MagnetPrice()
+	if before == 0 || after == 0 {
+		return 0
+	}
+	return (after - before) / curr.CurrentPrice * 100
+}
+
+// LevelFlow returns the net TotalVolume change per level between two
+// snapshots, keyed by the level price snapped to its Key. Levels that
+// appeared or vanished count in full. Nil is returned on a symbol mismatch
+func LevelFlow(prev, curr *HeatmapData) map[float64]float64 {
+	if prev == nil || curr == nil || prev.Symbol != curr.Symbol {
+		return nil
+	}
+
+	flow := make(map[float64]float64, len(curr.Levels))
+	for i := range prev.Levels {
+		flow[prev.Levels[i].keyPrice()] -= prev.Levels[i].TotalVolume
+	}
+	for i := range curr.Levels {
+		flow[curr.Levels[i].keyPrice()] += curr.Levels[i].TotalVolume
+	}
+	return flow
+}
+
+// MergeHeatmaps combines per-exchange heatmaps for symbol into one aggregate
+// heatmap with an empty Exchange. Levels are matched by price bucket and their
+// long and short volumes summed, then intensities and the summary are
+// recomputed; clusters are not carried over. CurrentPrice is the average of
+// the inputs' current prices weighted by their total level volume, falling
+// back to a simple average when no input has volume. Returns an error if no
+// heatmaps are given or their symbols or intervals differ
+func MergeHeatmaps(symbol Symbol, heatmaps ...HeatmapData) (HeatmapData, error) {
+	return MergeHeatmapsWithThreshold(symbol, DefaultSignificanceThreshold, heatmaps...)
+}
+
+// MergeHeatmapsWithThreshold merges like MergeHeatmaps, counting levels at or
+// above significanceThreshold toward Summary.SignificantLevels
+func MergeHeatmapsWithThreshold(symbol Symbol, significanceThreshold float64, heatmaps ...HeatmapData) (HeatmapData, error) {
+	if len(heatmaps) == 0 {
+		return HeatmapData{}, fmt.Errorf("no heatmaps to merge")
+	}
+
+	merged := HeatmapData{Symbol: symbol, Interval: heatmaps[0].Interval}
+	index := make(map[int64]int)
+	var weightedPrice, totalVolume, priceSum float64
+	for _, h := range heatmaps {
+		if h.Symbol != symbol {
+			return HeatmapData{}, fmt.Errorf("symbol mismatch: expected %s, got %s", symbol, h.Symbol)
+		}
+		if h.Interval != merged.Interval {
+			return HeatmapData{}, fmt.Errorf("interval mismatch: expected %s, got %s", merged.Interval, h.Interval)
+		}
+		if h.Timestamp > merged.Timestamp {
+			merged.Timestamp = h.Timestamp
+		}
+
+		var volume float64
+		for _, level := range h.Levels {
+			volume += level.TotalVolume
+			key := level.Key(defaultLevelKeySize)
+			i, ok := index[key]
+			if !ok {
+				i = len(merged.Levels)
+				index[key] = i
+				merged.Levels = append(merged.Levels, LiquidationLevel{
+					Price:           level.Price,
+					PriceLow:        level.PriceLow,
+					PriceHigh:       level.PriceHigh,
+					OldestTimestamp: level.OldestTimestamp,
+					NewestTimestamp: level.NewestTimestamp,
+					Timestamp:       level.Timestamp,
+				})
+			}
+			existing := &merged.Levels[i]
+			existing.LongLiquidations += level.LongLiquidations
+			existing.ShortLiquidations += level.ShortLiquidations
+			existing.TotalVolume = existing.LongLiquidations + existing.ShortLiquidations
+			if level.Timestamp > existing.Timestamp {
+				existing.Timestamp = level.Timestamp
+			}
+			if level.OldestTimestamp != 0 && (existing.OldestTimestamp == 0 || level.OldestTimestamp < existing.OldestTimestamp) {
+				existing.OldestTimestamp = level.OldestTimestamp
+			}
+			if level.NewestTimestamp > existing.NewestTimestamp {
+				existing.NewestTimestamp = level.NewestTimestamp
+			}
+		}
+		weightedPrice += h.CurrentPrice * volume
+		totalVolume += volume
+		priceSum += h.CurrentPrice
+	}
+
+	if totalVolume > 0 {
+		merged.CurrentPrice = weightedPrice / totalVolume
+	} else {
+		merged.CurrentPrice = priceSum / float64(len(heatmaps))
+	}
+
+	merged.Levels = sortedLevels(merged.Levels)
+	merged.normalizeIntensities()
+	merged.Summary = ComputeSummary(merged.Levels, significanceThreshold)
+	return merged, nil
+}
+
+// DetectClusters groups significant levels into clusters. Levels are sorted
+// by price and consecutive significant levels closer than maxGap share a
+// cluster; non-significant levels are skipped and do not break a run
+func DetectClusters(levels []LiquidationLevel, symbol Symbol, intensityThreshold float64, maxGap float64) []LiquidationCluster {
+	var clusters []LiquidationCluster
+	var current *LiquidationCluster
+
+	for _, level := range sortedLevels(levels) {
+		if !level.IsSignificant(intensityThreshold) {
+			continue
+		}
+
+		if current != nil && level.Price-current.PriceRangeEnd >= maxGap {
+			clusters = append(clusters, *current)
+			current = nil
+		}
+		if current == nil {
+			current = &LiquidationCluster{
+				Symbol:          symbol,
+				PriceRangeStart: level.Price,
+			}
+		}
+
+		current.PriceRangeEnd = level.Price
+		current.Levels = append(current.Levels, level)
+		current.TotalVolume += level.TotalVolume
+		current.PeakIntensity = math.Max(current.PeakIntensity, level.Intensity)
+		if level.Timestamp > current.UpdatedAt {
+			current.UpdatedAt = level.Timestamp
+		}
+	}
+	if current != nil {
+		clusters = append(clusters, *current)
+	}
+
+	return clusters
+}
+
+// criticalZoneDominance is the share of zone volume one side needs for the
+// zone to be typed by that side rather than "mixed"
+const criticalZoneDominance = 0.6
+
+// DefaultCriticalZoneIntensity is the intensity threshold used to detect
+// critical zones when a heatmap summary carries none
+const DefaultCriticalZoneIntensity = 70.0
+
+// DetectCriticalZones finds runs of consecutive price-sorted levels at or
+// above intensityThreshold. A zone never spans currentPrice, and a level
+// below the threshold ends the run. Zones are typed "long" or "short" when
+// that side holds more than 60% of the zone volume and "mixed" otherwise
+func DetectCriticalZones(levels []LiquidationLevel, currentPrice float64, intensityThreshold float64) []CriticalZone {
+	var zones []CriticalZone
+	var current *CriticalZone
+	var long, short float64
+	var above bool
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Type = "mixed"
+		if total := long + short; total > 0 {
+			if long/total > criticalZoneDominance {
+				current.Type = "long"
+			} else if short/total > criticalZoneDominance {
+				current.Type = "short"
+			}
+		}
+		zones = append(zones, *current)
+		current = nil
+		long, short = 0, 0
+	}
+
+	for _, level := range sortedLevels(levels) {
+		if !level.IsSignificant(intensityThreshold) {
+			flush()
+			continue
+		}
+
+		levelAbove := level.Price >= currentPrice
+		if current != nil && levelAbove != above {
+			flush()
+		}
+		if current == nil {
+			current = &CriticalZone{PriceStart: level.Price}
+			above = levelAbove
+		}
+
+		current.PriceEnd = level.Price
+		current.Intensity = math.Max(current.Intensity, level.Intensity)
+		current.Volume += level.TotalVolume
+		long += level.LongLiquidations
+		short += level.ShortLiquidations
+	}
+	flush()
+
+	return zones
+}
+
+// CriticalZoneCoverage returns the fraction of total liquidation volume held
+// by levels inside the heatmap's critical zones. Summary.CriticalZones is
+// used when present; otherwise zones are detected at
+// DefaultCriticalZoneIntensity
+func (h *HeatmapData) CriticalZoneCoverage() float64 {
+	zones := h.Summary.CriticalZones
+	if len(zones) == 0 {
+		zones = DetectCriticalZones(h.Levels, h.CurrentPrice, DefaultCriticalZoneIntensity)
+	}
+
+	var inside, total float64
+	for _, level := range h.Levels {
+		total += level.TotalVolume
+		for _, zone := range zones {
+			if level.Price >= zone.PriceStart && level.Price <= zone.PriceEnd {
+				inside += level.TotalVolume
+				break
+			}
+		}
+	}
+
+	if total <= 0 {
+		return 0
+	}
+	return inside / total
+}
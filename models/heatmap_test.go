@@ -0,0 +1,1227 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeatmapClone(t *testing.T) {
+	build := func() HeatmapData {
+		return HeatmapData{
+			Symbol:       SymbolBTCUSDT,
+			CurrentPrice: 45000.0,
+			Levels:       []LiquidationLevel{{Price: 44000.0, TotalVolume: 100000.0}},
+			Clusters: []LiquidationCluster{{
+				Symbol: SymbolBTCUSDT,
+				Levels: []LiquidationLevel{{Price: 44000.0, TotalVolume: 100000.0}},
+			}},
+			Summary: HeatmapSummary{
+				CriticalZones: []CriticalZone{{PriceStart: 43900.0, PriceEnd: 44100.0, Type: "long"}},
+			},
+		}
+	}
+	heatmap := build()
+
+	clone := heatmap.Clone()
+	clone.Levels[0].TotalVolume = 0
+	clone.Clusters[0].Levels[0].Price = 1.0
+	clone.Clusters[0].TotalVolume = 5.0
+	clone.Summary.CriticalZones[0].Type = "short"
+	clone.CurrentPrice = 1.0
+
+	if !reflect.DeepEqual(heatmap, build()) {
+		t.Errorf("Clone() mutation leaked into source: %+v", heatmap)
+	}
+}
+
+func TestApproachingWall(t *testing.T) {
+	heatmap := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, Intensity: 90.0},
+			{Price: 45200.0, Intensity: 20.0}, // not significant
+			{Price: 45500.0, Intensity: 80.0},
+			{Price: 46000.0, Intensity: 95.0},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		velocity  float64
+		lookAhead time.Duration
+		expected  float64
+		found     bool
+	}{
+		{
+			name:      "rising price hits nearest wall above",
+			velocity:  10.0, // $10 per second
+			lookAhead: time.Minute,
+			expected:  45500.0,
+			found:     true,
+		},
+		{
+			name:      "falling price hits wall below",
+			velocity:  -20.0,
+			lookAhead: time.Minute,
+			expected:  44000.0,
+			found:     true,
+		},
+		{
+			name:      "no wall in path",
+			velocity:  1.0,
+			lookAhead: time.Minute,
+			found:     false,
+		},
+		{
+			name:      "zero velocity",
+			velocity:  0,
+			lookAhead: time.Minute,
+			found:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, found := heatmap.ApproachingWall(tt.velocity, tt.lookAhead, 50.0)
+			if found != tt.found {
+				t.Fatalf("ApproachingWall() found = %v, expected %v", found, tt.found)
+			}
+			if tt.found && level.Price != tt.expected {
+				t.Errorf("ApproachingWall() price = %v, expected %v", level.Price, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecayLevels(t *testing.T) {
+	prev := []LiquidationLevel{
+		{Price: 44000.0, TotalVolume: 100000.0, Intensity: 100.0},
+		{Price: 45000.0, TotalVolume: 50000.0, Intensity: 80.0},
+	}
+	curr := []LiquidationLevel{
+		{Price: 45000.0, TotalVolume: 10000.0, Intensity: 20.0},
+		{Price: 46000.0, TotalVolume: 60000.0, Intensity: 60.0},
+	}
+
+	result := DecayLevels(prev, curr, 0.5)
+
+	byPrice := make(map[float64]LiquidationLevel)
+	for _, level := range result {
+		byPrice[level.Price] = level
+	}
+
+	if len(result) != 3 {
+		t.Fatalf("DecayLevels() returned %d levels, expected 3", len(result))
+	}
+
+	// Removed wall fades rather than disappearing
+	removed, ok := byPrice[44000.0]
+	if !ok {
+		t.Fatal("removed wall should persist after decay")
+	}
+	if removed.Intensity != 50.0 {
+		t.Errorf("removed wall intensity = %v, expected 50", removed.Intensity)
+	}
+	if removed.TotalVolume != 0 {
+		t.Errorf("removed wall volume = %v, expected 0", removed.TotalVolume)
+	}
+
+	// Decayed previous intensity outweighs the weaker current one
+	if byPrice[45000.0].Intensity != 40.0 {
+		t.Errorf("blended intensity = %v, expected 40", byPrice[45000.0].Intensity)
+	}
+
+	// New level keeps its own intensity
+	if byPrice[46000.0].Intensity != 60.0 {
+		t.Errorf("new level intensity = %v, expected 60", byPrice[46000.0].Intensity)
+	}
+
+	// A fully decayed wall is dropped
+	if result := DecayLevels(prev, nil, 0); len(result) != 0 {
+		t.Errorf("DecayLevels() with zero decay returned %d levels, expected 0", len(result))
+	}
+}
+
+func TestApplyTimeDecay(t *testing.T) {
+	now := int64(1700000000000)
+	halfLife := time.Hour
+	heatmap := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0, Timestamp: now},
+			{Price: 45000.0, LongLiquidations: 60000.0, ShortLiquidations: 40000.0, TotalVolume: 100000.0,
+				Timestamp: now - halfLife.Milliseconds()},
+			{Price: 46000.0, ShortLiquidations: 1e-3, TotalVolume: 1e-3, Timestamp: now - 100*halfLife.Milliseconds()},
+			{Price: 47000.0, ShortLiquidations: 25000.0, TotalVolume: 25000.0}, // no timestamp
+		},
+		Summary: HeatmapSummary{SignificantLevels: 4, CriticalZones: []CriticalZone{{PriceStart: 46000.0, PriceEnd: 46000.0}}},
+	}
+
+	heatmap.ApplyTimeDecay(now, halfLife)
+
+	if len(heatmap.Levels) != 3 {
+		t.Fatalf("ApplyTimeDecay() returned %d levels, expected 3", len(heatmap.Levels))
+	}
+
+	fresh := heatmap.Levels[0]
+	if fresh.TotalVolume != 100000.0 || fresh.Intensity != 100.0 {
+		t.Errorf("ApplyTimeDecay() fresh level = %v, %v, expected 100000, 100", fresh.TotalVolume, fresh.Intensity)
+	}
+
+	aged := heatmap.Levels[1]
+	if math.Abs(aged.TotalVolume-50000.0) > 1e-6 ||
+		math.Abs(aged.LongLiquidations-30000.0) > 1e-6 ||
+		math.Abs(aged.ShortLiquidations-20000.0) > 1e-6 {
+		t.Errorf("ApplyTimeDecay() aged level = %+v, expected volumes halved", aged)
+	}
+	if math.Abs(aged.Intensity-50.0) > 1e-6 {
+		t.Errorf("ApplyTimeDecay() aged intensity = %v, expected 50", aged.Intensity)
+	}
+
+	if undated := heatmap.Levels[2]; undated.Price != 47000.0 || undated.TotalVolume != 25000.0 {
+		t.Errorf("ApplyTimeDecay() undated level = %+v, expected it kept undecayed", undated)
+	}
+
+	summary := heatmap.Summary
+	if summary.TotalLongLiquidations != 130000.0 || math.Abs(summary.TotalShortLiquidations-45000.0) > 1e-6 {
+		t.Errorf("ApplyTimeDecay() summary totals = %v, %v, expected 130000, 45000",
+			summary.TotalLongLiquidations, summary.TotalShortLiquidations)
+	}
+	if summary.SignificantLevels != 2 || len(summary.CriticalZones) != 0 {
+		t.Errorf("ApplyTimeDecay() summary = %+v, expected 2 significant levels and no zones", summary)
+	}
+}
+
+func TestFindNearestLevel(t *testing.T) {
+	heatmap := HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0},
+			{Price: 45300.0},
+			{Price: 44800.0},
+			{Price: 47000.0},
+		},
+	}
+
+	level, ok := heatmap.FindNearestLevel()
+	if !ok || level.Price != 44800.0 {
+		t.Errorf("FindNearestLevel() = %v, %v, expected 44800, true", level.Price, ok)
+	}
+
+	empty := HeatmapData{CurrentPrice: 45000.0}
+	if level, ok := empty.FindNearestLevel(); ok || level.Price != 0 {
+		t.Errorf("FindNearestLevel() = %v, %v, expected zero level, false", level.Price, ok)
+	}
+}
+
+func TestLevelsWithinPercent(t *testing.T) {
+	heatmap := HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0}, // 2.2% below
+			{Price: 45300.0}, // 0.67% above
+			{Price: 44800.0}, // 0.44% below
+			{Price: 45900.0}, // 2% above
+			{Price: 47000.0}, // 4.4% above
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pct      float64
+		expected []float64
+	}{
+		{name: "one percent band", pct: 1.0, expected: []float64{44800.0, 45300.0}},
+		{name: "two percent band", pct: 2.0, expected: []float64{44800.0, 45300.0, 45900.0}},
+		{name: "zero band", pct: 0, expected: nil},
+		{name: "negative band", pct: -1.0, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := heatmap.LevelsWithinPercent(tt.pct)
+			var prices []float64
+			for _, level := range result {
+				prices = append(prices, level.Price)
+			}
+			if !reflect.DeepEqual(prices, tt.expected) {
+				t.Errorf("LevelsWithinPercent() = %v, expected %v", prices, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnnotateDistances(t *testing.T) {
+	heatmap := HeatmapData{
+		CurrentPrice: 40000.0,
+		Levels: []LiquidationLevel{
+			{Price: 36000.0}, // 10% below
+			{Price: 42000.0}, // 5% above
+			{Price: 40000.0}, // at price
+		},
+	}
+
+	heatmap.AnnotateDistances()
+
+	expected := []float64{-10.0, 5.0, 0}
+	for i, level := range heatmap.Levels {
+		if math.Abs(level.DistancePct-expected[i]) > 1e-9 {
+			t.Errorf("AnnotateDistances() level %v = %v, expected %v", level.Price, level.DistancePct, expected[i])
+		}
+	}
+
+	heatmap.CurrentPrice = 0
+	heatmap.AnnotateDistances()
+	for _, level := range heatmap.Levels {
+		if level.DistancePct != 0 {
+			t.Errorf("AnnotateDistances() with zero price = %v, expected 0", level.DistancePct)
+		}
+	}
+}
+
+func TestProximityScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		levels   []LiquidationLevel
+		expected float64
+	}{
+		{
+			name: "near wall",
+			levels: []LiquidationLevel{
+				{Price: 45450.0, Intensity: 80.0}, // 1% away
+				{Price: 49500.0, Intensity: 90.0},
+			},
+			expected: 50.0,
+		},
+		{
+			name: "distant wall",
+			levels: []LiquidationLevel{
+				{Price: 45450.0, Intensity: 10.0}, // not significant
+				{Price: 40500.0, Intensity: 90.0}, // 10% away
+			},
+			expected: 100.0 / 11,
+		},
+		{
+			name: "no wall",
+			levels: []LiquidationLevel{
+				{Price: 45450.0, Intensity: 10.0},
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heatmap := HeatmapData{CurrentPrice: 45000.0, Levels: tt.levels}
+			result := heatmap.ProximityScore(50.0)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("ProximityScore() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeProximityWeightedIntensity(t *testing.T) {
+	heatmap := HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 45450.0, TotalVolume: 100000.0}, // 1% away
+			{Price: 40500.0, TotalVolume: 100000.0}, // 10% away
+			{Price: 44550.0, TotalVolume: 50000.0},  // 1% away, half volume
+		},
+	}
+
+	heatmap.ComputeProximityWeightedIntensity()
+
+	near, far, small := heatmap.Levels[0], heatmap.Levels[1], heatmap.Levels[2]
+	if near.WeightedIntensity != 100.0 {
+		t.Errorf("near level WeightedIntensity = %v, expected 100", near.WeightedIntensity)
+	}
+	if far.WeightedIntensity >= near.WeightedIntensity {
+		t.Errorf("far level WeightedIntensity = %v, expected below near level %v",
+			far.WeightedIntensity, near.WeightedIntensity)
+	}
+	if math.Abs(far.WeightedIntensity-100.0*2/11) > 1e-9 {
+		t.Errorf("far level WeightedIntensity = %v, expected %v", far.WeightedIntensity, 100.0*2/11)
+	}
+	if small.WeightedIntensity != 50.0 {
+		t.Errorf("small level WeightedIntensity = %v, expected 50", small.WeightedIntensity)
+	}
+}
+
+func TestExtractWalls(t *testing.T) {
+	heatmap := HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			// Deliberately unsorted
+			{Price: 46000.0, ShortLiquidations: 300000.0},
+			{Price: 44000.0, LongLiquidations: 200000.0, ShortLiquidations: 10000.0},
+			{Price: 44100.0, LongLiquidations: 150000.0},
+			{Price: 45900.0, ShortLiquidations: 250000.0},
+			{Price: 45000.0}, // empty level breaks a run
+			{Price: 43000.0, ShortLiquidations: 5000.0}, // too small on its own
+			{Price: 46100.0, ShortLiquidations: 100000.0},
+		},
+	}
+
+	walls := heatmap.ExtractWalls(100000.0)
+	if len(walls) != 2 {
+		t.Fatalf("ExtractWalls() returned %d walls, expected 2: %+v", len(walls), walls)
+	}
+
+	expected := []Wall{
+		{PriceStart: 44000.0, PriceEnd: 44100.0, Side: "long", Volume: 360000.0},
+		{PriceStart: 45900.0, PriceEnd: 46100.0, Side: "short", Volume: 650000.0},
+	}
+	for i, want := range expected {
+		if walls[i] != want {
+			t.Errorf("wall[%d] = %+v, expected %+v", i, walls[i], want)
+		}
+	}
+}
+
+func TestRecomputeIntensities(t *testing.T) {
+	heatmap := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 2000000.0, Intensity: 40.0}, // scored against a larger source
+			{Price: 45000.0, TotalVolume: 500000.0, Intensity: 100.0},
+			{Price: 46000.0, TotalVolume: 250000.0, Intensity: 50.0},
+		},
+		Summary: HeatmapSummary{SignificantLevels: 2},
+	}
+
+	heatmap.RecomputeIntensities()
+
+	expected := []float64{100.0, 25.0, 12.5}
+	for i, level := range heatmap.Levels {
+		if level.Intensity != expected[i] {
+			t.Errorf("RecomputeIntensities() level %v intensity = %v, expected %v", level.Price, level.Intensity, expected[i])
+		}
+	}
+	if heatmap.Summary.SignificantLevels != 1 {
+		t.Errorf("RecomputeIntensities() significant levels = %v, expected 1", heatmap.Summary.SignificantLevels)
+	}
+
+	// A summary counting no significant levels is refreshed too
+	heatmap.Summary.SignificantLevels = 0
+	heatmap.RecomputeIntensitiesWithThreshold(20.0)
+	if heatmap.Summary.SignificantLevels != 2 {
+		t.Errorf("RecomputeIntensitiesWithThreshold(20) significant levels = %v, expected 2", heatmap.Summary.SignificantLevels)
+	}
+
+	zero := HeatmapData{
+		Levels:  []LiquidationLevel{{Price: 45000.0, Intensity: 80.0}},
+		Summary: HeatmapSummary{SignificantLevels: 1},
+	}
+	zero.RecomputeIntensities()
+	if zero.Levels[0].Intensity != 0 || zero.Summary.SignificantLevels != 0 {
+		t.Errorf("RecomputeIntensities() zero volume = %v, %v, expected 0, 0",
+			zero.Levels[0].Intensity, zero.Summary.SignificantLevels)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	heatmap := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    time.Now().UnixMilli(),
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 46000.0, ShortLiquidations: 50000.0, TotalVolume: 1.0, RelativeIntensity: math.Inf(1),
+				PriceLow: math.NaN(), PriceHigh: math.Inf(-1), OIFraction: math.NaN()},
+			{Price: 44000.0, LongLiquidations: 60000.0, TotalVolume: 60000.0, DistancePct: math.NaN()},
+			{Price: 44000.0, LongLiquidations: 40000.0, TotalVolume: 40000.0},
+			{Price: 45000.0, LongLiquidations: math.NaN(), ShortLiquidations: 25000.0, Intensity: math.Inf(1)},
+			{Price: math.NaN(), LongLiquidations: 10000.0, TotalVolume: 10000.0},
+			{Price: 0, ShortLiquidations: 5000.0, TotalVolume: 5000.0},
+			{Price: -1.0, ShortLiquidations: 5000.0, TotalVolume: 5000.0},
+		},
+	}
+
+	repairs := heatmap.Repair()
+	dropped := 0
+	for _, repair := range repairs {
+		if strings.HasPrefix(repair, "dropped level") {
+			dropped++
+		}
+	}
+	if dropped != 3 {
+		t.Errorf("Repair() reported %d dropped levels, expected 3: %v", dropped, repairs)
+	}
+	if len(repairs) == 0 {
+		t.Fatal("Repair() should report the repairs it performed")
+	}
+
+	if err := heatmap.Validate(); err != nil {
+		t.Errorf("Validate() after Repair() error = %v", err)
+	}
+	if _, err := json.Marshal(heatmap); err != nil {
+		t.Errorf("json.Marshal() after Repair() error = %v", err)
+	}
+
+	if len(heatmap.Levels) != 3 {
+		t.Fatalf("Repair() left %d levels, expected 3", len(heatmap.Levels))
+	}
+
+	for i, level := range heatmap.Levels {
+		if i > 0 && level.Price <= heatmap.Levels[i-1].Price {
+			t.Errorf("levels not sorted at index %d", i)
+		}
+		if level.TotalVolume != level.LongLiquidations+level.ShortLiquidations {
+			t.Errorf("level %v TotalVolume = %v, expected long+short", level.Price, level.TotalVolume)
+		}
+		if math.IsNaN(level.Intensity) || math.IsInf(level.Intensity, 0) {
+			t.Errorf("level %v has non-finite intensity", level.Price)
+		}
+	}
+
+	if heatmap.Levels[0].TotalVolume != 100000.0 || heatmap.Levels[0].Intensity != 100.0 {
+		t.Errorf("coalesced level = %+v, expected volume 100000 and intensity 100", heatmap.Levels[0])
+	}
+
+	if heatmap.Summary.TotalLongLiquidations != 100000.0 {
+		t.Errorf("TotalLongLiquidations = %v, expected 100000", heatmap.Summary.TotalLongLiquidations)
+	}
+
+	if again := heatmap.Repair(); len(again) != 0 {
+		t.Errorf("Repair() on a repaired heatmap = %v, expected no repairs", again)
+	}
+}
+
+func TestCascadeMultiplier(t *testing.T) {
+	dense := HeatmapData{
+		CurrentPrice: 100.0,
+		Levels: []LiquidationLevel{
+			{Price: 99.5, LongLiquidations: 1000000.0},  // hit by the initial 1% drop
+			{Price: 98.5, LongLiquidations: 1000000.0},  // hit after the first wave
+			{Price: 97.5, LongLiquidations: 1000000.0},  // hit after the second wave
+			{Price: 90.0, LongLiquidations: 5000000.0},  // out of reach
+			{Price: 99.0, ShortLiquidations: 9000000.0}, // wrong side
+		},
+	}
+	sparse := HeatmapData{
+		CurrentPrice: 100.0,
+		Levels: []LiquidationLevel{
+			{Price: 80.0, LongLiquidations: 1000000.0},
+		},
+	}
+
+	if result := dense.CascadeMultiplier(-1.0); math.Abs(result-4.0) > 1e-9 {
+		t.Errorf("dense CascadeMultiplier() = %v, expected 4", result)
+	}
+	if result := sparse.CascadeMultiplier(-1.0); result != 1.0 {
+		t.Errorf("sparse CascadeMultiplier() = %v, expected 1", result)
+	}
+	if result := dense.CascadeMultiplier(0); result != 0 {
+		t.Errorf("CascadeMultiplier() with no move = %v, expected 0", result)
+	}
+}
+
+func TestVolumeDensity(t *testing.T) {
+	heatmap := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 46000.0, TotalVolume: 50000.0},
+			{Price: 44000.0, TotalVolume: 100000.0},
+			{Price: 45000.0, TotalVolume: 200000.0},
+		},
+	}
+	density := heatmap.VolumeDensity()
+
+	tests := []struct {
+		name     string
+		price    float64
+		expected float64
+	}{
+		{name: "on a level", price: 45000.0, expected: 200000.0},
+		{name: "between levels", price: 44250.0, expected: 125000.0},
+		{name: "between upper levels", price: 45500.0, expected: 125000.0},
+		{name: "lowest level", price: 44000.0, expected: 100000.0},
+		{name: "below range", price: 43000.0, expected: 0},
+		{name: "above range", price: 47000.0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := density(tt.price)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("VolumeDensity()(%v) = %v, expected %v", tt.price, result, tt.expected)
+			}
+		})
+	}
+
+	empty := HeatmapData{}
+	if result := empty.VolumeDensity()(45000.0); result != 0 {
+		t.Errorf("VolumeDensity() on empty heatmap = %v, expected 0", result)
+	}
+}
+
+func TestLevelKey(t *testing.T) {
+	x, y := 0.1, 0.2
+	a := LiquidationLevel{Price: x + y}
+	b := LiquidationLevel{Price: 0.3}
+	if a.Price == b.Price {
+		t.Fatal("test prices should differ by float rounding")
+	}
+	if a.Key(0) != b.Key(0) {
+		t.Errorf("Key() = %v and %v, expected equal keys", a.Key(0), b.Key(0))
+	}
+
+	tests := []struct {
+		name       string
+		price      float64
+		bucketSize float64
+		expected   int64
+	}{
+		{name: "exact bucket", price: 45000.0, bucketSize: 50.0, expected: 900},
+		{name: "rounds to nearest bucket", price: 45024.9, bucketSize: 50.0, expected: 900},
+		{name: "rounds up", price: 45025.1, bucketSize: 50.0, expected: 901},
+		{name: "sub-dollar bucket", price: 0.5123, bucketSize: 0.001, expected: 512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := LiquidationLevel{Price: tt.price}
+			if result := level.Key(tt.bucketSize); result != tt.expected {
+				t.Errorf("Key() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	// Levels that differ only by rounding are matched when decaying
+	prev := []LiquidationLevel{{Price: x + y, Intensity: 80.0}}
+	curr := []LiquidationLevel{{Price: 0.3, Intensity: 10.0}}
+	if result := DecayLevels(prev, curr, 0.5); len(result) != 1 || result[0].Intensity != 40.0 {
+		t.Errorf("DecayLevels() = %+v, expected a single blended level", result)
+	}
+}
+
+func TestWeightedMedianPrice(t *testing.T) {
+	// Skewed: a far outlier drags the mean but not the median
+	skewed := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 300000.0},
+			{Price: 44100.0, TotalVolume: 300000.0},
+			{Price: 44200.0, TotalVolume: 100000.0},
+			{Price: 60000.0, TotalVolume: 300000.0},
+		},
+	}
+
+	median := skewed.WeightedMedianPrice()
+	if median != 44100.0 {
+		t.Errorf("WeightedMedianPrice() = %v, expected 44100", median)
+	}
+
+	var weighted, total float64
+	for _, level := range skewed.Levels {
+		weighted += level.Price * level.TotalVolume
+		total += level.TotalVolume
+	}
+	if mean := weighted / total; mean-median < 1000 {
+		t.Errorf("mean %v should sit far above the median %v on a skewed distribution", mean, median)
+	}
+
+	// Even split interpolates between the two middle levels
+	even := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 100000.0},
+			{Price: 46000.0, TotalVolume: 100000.0},
+		},
+	}
+	if result := even.WeightedMedianPrice(); result != 45000.0 {
+		t.Errorf("WeightedMedianPrice() on even split = %v, expected 45000", result)
+	}
+
+	empty := HeatmapData{}
+	if result := empty.WeightedMedianPrice(); result != 0 {
+		t.Errorf("WeightedMedianPrice() on empty heatmap = %v, expected 0", result)
+	}
+}
+
+func TestLevelStrength(t *testing.T) {
+	heatmap := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 10000.0},
+			{Price: 44500.0, TotalVolume: 10000.0},
+			{Price: 45000.0, TotalVolume: 10000.0},
+			{Price: 45500.0, TotalVolume: 170000.0}, // dominant wall
+		},
+	}
+
+	avg := heatmap.AverageLevelVolume()
+	if avg != 50000.0 {
+		t.Fatalf("AverageLevelVolume() = %v, expected 50000", avg)
+	}
+
+	if result := heatmap.Levels[3].Strength(avg); result != 3.4 {
+		t.Errorf("dominant Strength() = %v, expected 3.4", result)
+	}
+	if result := heatmap.Levels[0].Strength(avg); result != 0.2 {
+		t.Errorf("minor Strength() = %v, expected 0.2", result)
+	}
+	if result := heatmap.Levels[3].Strength(0); result != 0 {
+		t.Errorf("Strength() with zero average = %v, expected 0", result)
+	}
+
+	empty := HeatmapData{}
+	if result := empty.AverageLevelVolume(); result != 0 {
+		t.Errorf("AverageLevelVolume() on empty heatmap = %v, expected 0", result)
+	}
+}
+
+func TestImminentRiskRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		levels   []LiquidationLevel
+		expected float64
+	}{
+		{
+			name: "concentrated near price",
+			levels: []LiquidationLevel{
+				{Price: 44800.0, TotalVolume: 400000.0},
+				{Price: 45300.0, TotalVolume: 500000.0},
+				{Price: 40000.0, TotalVolume: 100000.0},
+			},
+			expected: 0.9,
+		},
+		{
+			name: "spread out",
+			levels: []LiquidationLevel{
+				{Price: 44900.0, TotalVolume: 100000.0},
+				{Price: 40000.0, TotalVolume: 450000.0},
+				{Price: 50000.0, TotalVolume: 450000.0},
+			},
+			expected: 0.1,
+		},
+		{
+			name:     "no volume",
+			levels:   []LiquidationLevel{{Price: 45000.0}},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heatmap := HeatmapData{CurrentPrice: 45000.0, Levels: tt.levels}
+			result := heatmap.ImminentRiskRatio(1.0)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("ImminentRiskRatio() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLiquidationImbalance(t *testing.T) {
+	heatmap := HeatmapData{
+		Levels: []LiquidationLevel{
+			{LongLiquidations: 300000.0, ShortLiquidations: 50000.0},
+			{ShortLiquidations: 50000.0},
+		},
+	}
+	if result := heatmap.LiquidationImbalance(); math.Abs(result-0.5) > 1e-9 {
+		t.Errorf("LiquidationImbalance() = %v, expected 0.5", result)
+	}
+
+	empty := HeatmapData{}
+	if result := empty.LiquidationImbalance(); result != 0 {
+		t.Errorf("LiquidationImbalance() on empty heatmap = %v, expected 0", result)
+	}
+}
+
+func TestBiasDivergence(t *testing.T) {
+	shortTerm := &HeatmapData{
+		Symbol:   SymbolBTCUSDT,
+		Interval: Interval1m,
+		Levels:   []LiquidationLevel{{LongLiquidations: 80000.0, ShortLiquidations: 20000.0}},
+	}
+	longTerm := &HeatmapData{
+		Symbol:   SymbolBTCUSDT,
+		Interval: Interval1h,
+		Levels:   []LiquidationLevel{{LongLiquidations: 30000.0, ShortLiquidations: 70000.0}},
+	}
+
+	if result := BiasDivergence(shortTerm, longTerm); math.Abs(result-1.0) > 1e-9 {
+		t.Errorf("BiasDivergence() = %v, expected 1.0", result)
+	}
+	if result := BiasDivergence(longTerm, shortTerm); math.Abs(result+1.0) > 1e-9 {
+		t.Errorf("reversed BiasDivergence() = %v, expected -1.0", result)
+	}
+
+	other := &HeatmapData{Symbol: SymbolETHUSDT, Levels: longTerm.Levels}
+	if result := BiasDivergence(shortTerm, other); result != 0 {
+		t.Errorf("BiasDivergence() with mismatched symbols = %v, expected 0", result)
+	}
+}
+
+func TestSupportResistance(t *testing.T) {
+	heatmap := HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 46500.0, ShortLiquidations: 90000.0, Intensity: 90.0},
+			{Price: 43000.0, LongLiquidations: 90000.0, Intensity: 90.0},
+			{Price: 44500.0, LongLiquidations: 70000.0, Intensity: 70.0},
+			{Price: 44000.0, ShortLiquidations: 80000.0, Intensity: 80.0}, // short zone below price
+			{Price: 45500.0, ShortLiquidations: 60000.0, Intensity: 60.0},
+			{Price: 46000.0, LongLiquidations: 80000.0, Intensity: 80.0},  // long zone above price
+			{Price: 47000.0, ShortLiquidations: 10000.0, Intensity: 10.0}, // not significant
+		},
+	}
+
+	support, resistance := heatmap.SupportResistance(50.0)
+
+	expectedSupport := []float64{44500.0, 43000.0}
+	expectedResistance := []float64{45500.0, 46500.0}
+	if !reflect.DeepEqual(support, expectedSupport) {
+		t.Errorf("support = %v, expected %v", support, expectedSupport)
+	}
+	if !reflect.DeepEqual(resistance, expectedResistance) {
+		t.Errorf("resistance = %v, expected %v", resistance, expectedResistance)
+	}
+}
+
+func TestCascadeTargetPrice(t *testing.T) {
+	wallHeavy := HeatmapData{
+		CurrentPrice: 100.0,
+		Levels: []LiquidationLevel{
+			{Price: 101.5, ShortLiquidations: 2000000.0},
+			{Price: 103.5, ShortLiquidations: 2000000.0},
+			{Price: 110.0, ShortLiquidations: 2000000.0},
+		},
+	}
+	thin := HeatmapData{
+		CurrentPrice: 100.0,
+		Levels: []LiquidationLevel{
+			{Price: 101.5, ShortLiquidations: 100000.0},
+		},
+	}
+
+	// 2% up hits 101.5 ($2M -> +2%), then 103.5 ($2M -> +2%) and settles at +6%
+	if result := wallHeavy.CascadeTargetPrice(2.0, 1.0); math.Abs(result-106.0) > 1e-9 {
+		t.Errorf("wall-heavy CascadeTargetPrice() = %v, expected 106", result)
+	}
+	if result := thin.CascadeTargetPrice(2.0, 1.0); math.Abs(result-102.1) > 1e-9 {
+		t.Errorf("thin CascadeTargetPrice() = %v, expected 102.1", result)
+	}
+	if result := thin.CascadeTargetPrice(0, 1.0); result != 100.0 {
+		t.Errorf("CascadeTargetPrice() with no move = %v, expected 100", result)
+	}
+}
+
+func TestMagnetDrift(t *testing.T) {
+	prev := &HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 100000.0},
+			{Price: 46000.0, TotalVolume: 100000.0},
+		},
+	}
+	curr := &HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 50000.0},
+			{Price: 46000.0, TotalVolume: 150000.0},
+		},
+	}
+
+	if magnet := curr.MagnetPrice(); magnet != 45500.0 {
+		t.Errorf("MagnetPrice() = %v, expected 45500", magnet)
+	}
+
+	// Magnet rose from 45000 to 45500, 500/45000 of current price
+	drift := MagnetDrift(prev, curr)
+	if math.Abs(drift-500.0/45000*100) > 1e-9 {
+		t.Errorf("MagnetDrift() = %v, expected %v", drift, 500.0/45000*100)
+	}
+	if drift <= 0 {
+		t.Error("MagnetDrift() should be positive when the magnet rose")
+	}
+
+	other := &HeatmapData{Symbol: SymbolETHUSDT, CurrentPrice: 2500.0, Levels: curr.Levels}
+	if result := MagnetDrift(prev, other); result != 0 {
+		t.Errorf("MagnetDrift() with mismatched symbols = %v, expected 0", result)
+	}
+}
+
+func TestDetectClusters(t *testing.T) {
+	levels := []LiquidationLevel{
+		{Price: 44100.0, TotalVolume: 80000.0, Intensity: 80.0, Timestamp: 2},
+		{Price: 44000.0, TotalVolume: 60000.0, Intensity: 60.0, Timestamp: 1},
+		{Price: 44150.0, TotalVolume: 10000.0, Intensity: 10.0}, // not significant, skipped
+		{Price: 44200.0, TotalVolume: 70000.0, Intensity: 70.0, Timestamp: 3},
+		{Price: 46000.0, TotalVolume: 100000.0, Intensity: 100.0, Timestamp: 4},
+		{Price: 46050.0, TotalVolume: 55000.0, Intensity: 55.0, Timestamp: 5},
+	}
+
+	clusters := DetectClusters(levels, SymbolBTCUSDT, 50.0, 150.0)
+	if len(clusters) != 2 {
+		t.Fatalf("DetectClusters() returned %d clusters, expected 2", len(clusters))
+	}
+
+	first, second := clusters[0], clusters[1]
+	if first.PriceRangeStart != 44000.0 || first.PriceRangeEnd != 44200.0 {
+		t.Errorf("first cluster range = %v-%v, expected 44000-44200", first.PriceRangeStart, first.PriceRangeEnd)
+	}
+	if first.TotalVolume != 210000.0 || first.PeakIntensity != 80.0 || len(first.Levels) != 3 {
+		t.Errorf("first cluster = %+v", first)
+	}
+	if first.UpdatedAt != 3 || first.Symbol != SymbolBTCUSDT {
+		t.Errorf("first cluster UpdatedAt/Symbol = %v/%v", first.UpdatedAt, first.Symbol)
+	}
+	if second.PriceRangeStart != 46000.0 || second.PriceRangeEnd != 46050.0 || second.TotalVolume != 155000.0 {
+		t.Errorf("second cluster = %+v", second)
+	}
+
+	// Gaps of maxGap or more break the first group into single-level clusters
+	if clusters := DetectClusters(levels, SymbolBTCUSDT, 50.0, 100.0); len(clusters) != 4 {
+		t.Errorf("DetectClusters() with tight gap returned %d clusters, expected 4", len(clusters))
+	}
+
+	if clusters := DetectClusters(levels, SymbolBTCUSDT, 101.0, 150.0); len(clusters) != 0 {
+		t.Errorf("DetectClusters() without significant levels returned %d clusters, expected 0", len(clusters))
+	}
+}
+
+func TestComputeSummary(t *testing.T) {
+	levels := []LiquidationLevel{
+		{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0, Intensity: 50.0},
+		{Price: 44500.0, LongLiquidations: 300000.0, ShortLiquidations: 100000.0, TotalVolume: 400000.0, Intensity: 100.0},
+		{Price: 46000.0, ShortLiquidations: 300000.0, TotalVolume: 300000.0, Intensity: 75.0},
+		{Price: 47000.0, ShortLiquidations: 20000.0, TotalVolume: 20000.0, Intensity: 5.0},
+	}
+
+	summary := ComputeSummary(levels, 50.0)
+
+	if summary.TotalLongLiquidations != 400000.0 {
+		t.Errorf("TotalLongLiquidations = %v, expected 400000", summary.TotalLongLiquidations)
+	}
+	if summary.TotalShortLiquidations != 420000.0 {
+		t.Errorf("TotalShortLiquidations = %v, expected 420000", summary.TotalShortLiquidations)
+	}
+	if summary.MaxLiquidationPrice != 44500.0 || summary.MaxLiquidationVolume != 400000.0 {
+		t.Errorf("max level = %v @ %v, expected 400000 @ 44500",
+			summary.MaxLiquidationVolume, summary.MaxLiquidationPrice)
+	}
+
+	expectedLong := (44000.0*100000.0 + 44500.0*300000.0) / 400000.0
+	if math.Abs(summary.WeightedAvgLongPrice-expectedLong) > 1e-9 {
+		t.Errorf("WeightedAvgLongPrice = %v, expected %v", summary.WeightedAvgLongPrice, expectedLong)
+	}
+	expectedShort := (44500.0*100000.0 + 46000.0*300000.0 + 47000.0*20000.0) / 420000.0
+	if math.Abs(summary.WeightedAvgShortPrice-expectedShort) > 1e-9 {
+		t.Errorf("WeightedAvgShortPrice = %v, expected %v", summary.WeightedAvgShortPrice, expectedShort)
+	}
+
+	if summary.SignificantLevels != 3 {
+		t.Errorf("SignificantLevels = %v, expected 3", summary.SignificantLevels)
+	}
+	if len(summary.CriticalZones) != 0 {
+		t.Errorf("CriticalZones = %v, expected empty", summary.CriticalZones)
+	}
+
+	empty := ComputeSummary(nil, 50.0)
+	if !reflect.DeepEqual(empty, HeatmapSummary{}) {
+		t.Errorf("ComputeSummary(nil) = %+v, expected zero summary", empty)
+	}
+}
+
+func TestDetectCriticalZones(t *testing.T) {
+	levels := []LiquidationLevel{
+		// Long zone below price
+		{Price: 44000.0, LongLiquidations: 90000.0, ShortLiquidations: 10000.0, TotalVolume: 100000.0, Intensity: 80.0},
+		{Price: 44100.0, LongLiquidations: 70000.0, TotalVolume: 70000.0, Intensity: 60.0},
+		{Price: 44200.0, LongLiquidations: 5000.0, TotalVolume: 5000.0, Intensity: 5.0}, // breaks the run
+		// Balanced zone below price, ends at the current price boundary
+		{Price: 44800.0, LongLiquidations: 50000.0, ShortLiquidations: 50000.0, TotalVolume: 100000.0, Intensity: 90.0},
+		// Short zone above price
+		{Price: 45200.0, ShortLiquidations: 120000.0, TotalVolume: 120000.0, Intensity: 100.0},
+	}
+
+	zones := DetectCriticalZones(levels, 45000.0, 50.0)
+	expected := []CriticalZone{
+		{PriceStart: 44000.0, PriceEnd: 44100.0, Type: "long", Intensity: 80.0, Volume: 170000.0},
+		{PriceStart: 44800.0, PriceEnd: 44800.0, Type: "mixed", Intensity: 90.0, Volume: 100000.0},
+		{PriceStart: 45200.0, PriceEnd: 45200.0, Type: "short", Intensity: 100.0, Volume: 120000.0},
+	}
+
+	if !reflect.DeepEqual(zones, expected) {
+		t.Errorf("DetectCriticalZones() = %+v, expected %+v", zones, expected)
+	}
+}
+
+func TestLevelFlow(t *testing.T) {
+	prev := &HeatmapData{
+		Symbol: SymbolBTCUSDT,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 100000.0},
+			{Price: 45500.0, TotalVolume: 80000.0},
+			{Price: 46000.0, TotalVolume: 20000.0},
+		},
+	}
+	x, y := 44000.1, 0.2
+	curr := &HeatmapData{
+		Symbol: SymbolBTCUSDT,
+		Levels: []LiquidationLevel{
+			{Price: x + y - 0.3, TotalVolume: 150000.0}, // grew, float noise on the price
+			{Price: 45500.0, TotalVolume: 30000.0},      // shrank
+			{Price: 47000.0, TotalVolume: 60000.0},      // appeared
+		},
+	}
+
+	flow := LevelFlow(prev, curr)
+	expected := map[float64]float64{
+		44000.0: 50000.0,
+		45500.0: -50000.0,
+		46000.0: -20000.0,
+		47000.0: 60000.0,
+	}
+	if !reflect.DeepEqual(flow, expected) {
+		t.Errorf("LevelFlow() = %v, expected %v", flow, expected)
+	}
+
+	other := &HeatmapData{Symbol: SymbolETHUSDT}
+	if flow := LevelFlow(prev, other); flow != nil {
+		t.Errorf("LevelFlow() with mismatched symbols = %v, expected nil", flow)
+	}
+}
+
+func TestMergeHeatmaps(t *testing.T) {
+	binance := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Exchange:     ExchangeBinance,
+		Timestamp:    1000,
+		Interval:     Interval1m,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, LongLiquidations: 300000.0, TotalVolume: 300000.0},
+			{Price: 46000.0, ShortLiquidations: 100000.0, TotalVolume: 100000.0},
+		},
+	}
+	bybit := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Exchange:     ExchangeBybit,
+		Timestamp:    2000,
+		Interval:     Interval1m,
+		CurrentPrice: 45100.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0},
+			{Price: 47000.0, ShortLiquidations: 200000.0, TotalVolume: 200000.0},
+			{Price: 46000.0, ShortLiquidations: 100000.0, TotalVolume: 100000.0},
+		},
+	}
+
+	merged, err := MergeHeatmaps(SymbolBTCUSDT, binance, bybit)
+	if err != nil {
+		t.Fatalf("MergeHeatmaps() error = %v", err)
+	}
+	if merged.Exchange != "" || merged.Timestamp != 2000 || merged.Interval != Interval1m {
+		t.Errorf("MergeHeatmaps() header = %v, %v, %v, expected aggregate, 2000, 1m",
+			merged.Exchange, merged.Timestamp, merged.Interval)
+	}
+
+	expected := []LiquidationLevel{
+		{Price: 44000.0, LongLiquidations: 400000.0, TotalVolume: 400000.0, Intensity: 100.0},
+		{Price: 46000.0, ShortLiquidations: 200000.0, TotalVolume: 200000.0, Intensity: 50.0},
+		{Price: 47000.0, ShortLiquidations: 200000.0, TotalVolume: 200000.0, Intensity: 50.0},
+	}
+	if !reflect.DeepEqual(merged.Levels, expected) {
+		t.Errorf("MergeHeatmaps() levels = %+v, expected %+v", merged.Levels, expected)
+	}
+
+	// 400k of volume at 45000 and 400k at 45100
+	if math.Abs(merged.CurrentPrice-45050.0) > 1e-9 {
+		t.Errorf("MergeHeatmaps() current price = %v, expected 45050", merged.CurrentPrice)
+	}
+	if merged.Summary.TotalLongLiquidations != 400000.0 || merged.Summary.TotalShortLiquidations != 400000.0 {
+		t.Errorf("MergeHeatmaps() summary totals = %v, %v, expected 400000, 400000",
+			merged.Summary.TotalLongLiquidations, merged.Summary.TotalShortLiquidations)
+	}
+	if merged.Summary.SignificantLevels != 3 {
+		t.Errorf("MergeHeatmaps() significant levels = %v, expected 3", merged.Summary.SignificantLevels)
+	}
+	strict, err := MergeHeatmapsWithThreshold(SymbolBTCUSDT, 75.0, binance, bybit)
+	if err != nil || strict.Summary.SignificantLevels != 1 {
+		t.Errorf("MergeHeatmapsWithThreshold(75) significant levels = %v, %v, expected 1",
+			strict.Summary.SignificantLevels, err)
+	}
+
+	eth := HeatmapData{Symbol: SymbolETHUSDT, Interval: Interval1m}
+	if _, err := MergeHeatmaps(SymbolBTCUSDT, binance, eth); err == nil {
+		t.Error("MergeHeatmaps() with mismatched symbols expected error")
+	}
+	hourly := binance
+	hourly.Interval = Interval1h
+	if _, err := MergeHeatmaps(SymbolBTCUSDT, binance, hourly); err == nil {
+		t.Error("MergeHeatmaps() with mismatched intervals expected error")
+	}
+	if _, err := MergeHeatmaps(SymbolBTCUSDT); err == nil {
+		t.Error("MergeHeatmaps() with no heatmaps expected error")
+	}
+}
+
+func TestPressureGradient(t *testing.T) {
+	heatmap := &HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44500.0, LongLiquidations: 300000.0, ShortLiquidations: 5000.0}, // bin 0
+			{Price: 45500.0, ShortLiquidations: 100000.0, LongLiquidations: 5000.0}, // bin 0
+			{Price: 43800.0, LongLiquidations: 50000.0},                             // bin 1
+			{Price: 46200.0, ShortLiquidations: 200000.0},                           // bin 1
+			{Price: 43000.0, LongLiquidations: 400000.0},                            // farthest, last bin
+		},
+	}
+
+	gradient := heatmap.PressureGradient(2)
+	expected := []float64{200000.0, 250000.0}
+	if !reflect.DeepEqual(gradient, expected) {
+		t.Errorf("PressureGradient() = %v, expected %v", gradient, expected)
+	}
+	for i, pressure := range gradient {
+		if pressure <= 0 {
+			t.Errorf("PressureGradient()[%d] = %v, expected positive for long-heavy book below price", i, pressure)
+		}
+	}
+
+	if gradient := heatmap.PressureGradient(0); gradient != nil {
+		t.Errorf("PressureGradient(0) = %v, expected nil", gradient)
+	}
+}
+
+func TestVolumeQuantiles(t *testing.T) {
+	heatmap := HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44200.0, TotalVolume: 100000.0},
+			{Price: 44000.0, TotalVolume: 300000.0},
+			{Price: 60000.0, TotalVolume: 300000.0},
+			{Price: 44100.0, TotalVolume: 300000.0},
+		},
+	}
+
+	quantiles := heatmap.VolumeQuantiles([]float64{0.25, 0.5, 0.75, 1.5})
+	expected := []float64{44000.0, 44100.0, 60000.0, 60000.0}
+	if !reflect.DeepEqual(quantiles, expected) {
+		t.Errorf("VolumeQuantiles() = %v, expected %v", quantiles, expected)
+	}
+	if median := heatmap.WeightedMedianPrice(); quantiles[1] != median {
+		t.Errorf("VolumeQuantiles() median = %v, expected WeightedMedianPrice() %v", quantiles[1], median)
+	}
+
+	empty := HeatmapData{}
+	if quantiles := empty.VolumeQuantiles([]float64{0.5}); !reflect.DeepEqual(quantiles, []float64{0}) {
+		t.Errorf("VolumeQuantiles() on empty heatmap = %v, expected [0]", quantiles)
+	}
+}
+
+func TestCriticalZoneCoverage(t *testing.T) {
+	concentrated := &HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 400000.0, Intensity: 100.0},
+			{Price: 44100.0, TotalVolume: 300000.0, Intensity: 75.0},
+			{Price: 44500.0, TotalVolume: 50000.0, Intensity: 12.5},
+			{Price: 46000.0, TotalVolume: 200000.0, Intensity: 80.0},
+			{Price: 47000.0, TotalVolume: 50000.0, Intensity: 12.5},
+		},
+	}
+	if coverage := concentrated.CriticalZoneCoverage(); math.Abs(coverage-0.9) > 1e-9 {
+		t.Errorf("CriticalZoneCoverage() = %v, expected 0.9", coverage)
+	}
+
+	diffuse := &HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 100000.0, Intensity: 100.0},
+			{Price: 44200.0, TotalVolume: 90000.0, Intensity: 60.0},
+			{Price: 44400.0, TotalVolume: 95000.0, Intensity: 65.0},
+			{Price: 45600.0, TotalVolume: 90000.0, Intensity: 60.0},
+			{Price: 45800.0, TotalVolume: 125000.0, Intensity: 50.0},
+		},
+	}
+	if coverage := diffuse.CriticalZoneCoverage(); math.Abs(coverage-0.2) > 1e-9 {
+		t.Errorf("CriticalZoneCoverage() = %v, expected 0.2", coverage)
+	}
+
+	// Zones already in the summary take precedence over detection
+	diffuse.Summary.CriticalZones = []CriticalZone{{PriceStart: 44000.0, PriceEnd: 44400.0}}
+	if coverage := diffuse.CriticalZoneCoverage(); math.Abs(coverage-0.57) > 1e-9 {
+		t.Errorf("CriticalZoneCoverage() with summary zones = %v, expected 0.57", coverage)
+	}
+
+	if coverage := (&HeatmapData{}).CriticalZoneCoverage(); coverage != 0 {
+		t.Errorf("CriticalZoneCoverage() on empty heatmap = %v, expected 0", coverage)
+	}
+}
+
+func TestWallSpread(t *testing.T) {
+	tight := &HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, LongLiquidations: 500000.0, Intensity: 100.0},
+			{Price: 44775.0, LongLiquidations: 200000.0, Intensity: 80.0},
+			{Price: 45225.0, ShortLiquidations: 300000.0, Intensity: 90.0},
+			{Price: 45100.0, ShortLiquidations: 10000.0, Intensity: 5.0}, // below threshold
+		},
+	}
+	if spread := tight.WallSpread(50.0); math.Abs(spread-1.0) > 1e-9 {
+		t.Errorf("WallSpread() tight = %v, expected 1", spread)
+	}
+
+	wide := &HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 42750.0, LongLiquidations: 500000.0, Intensity: 100.0},
+			{Price: 47250.0, ShortLiquidations: 400000.0, Intensity: 80.0},
+		},
+	}
+	if spread := wide.WallSpread(50.0); math.Abs(spread-10.0) > 1e-9 {
+		t.Errorf("WallSpread() wide = %v, expected 10", spread)
+	}
+
+	oneSided := &HeatmapData{
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, LongLiquidations: 500000.0, Intensity: 100.0},
+		},
+	}
+	if spread := oneSided.WallSpread(50.0); spread != NoWallSpread {
+		t.Errorf("WallSpread() without short wall = %v, expected %v", spread, NoWallSpread)
+	}
+}
+
+func TestRelativeIntensity(t *testing.T) {
+	quiet := &HeatmapData{
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, TotalVolume: 50000.0},
+			{Price: 46000.0, TotalVolume: 10000.0},
+		},
+	}
+	for i := range quiet.Levels {
+		quiet.Levels[i].CalculateIntensity(50000.0)
+	}
+
+	// A busy reference week averages a 500k max level
+	quiet.RelativeIntensity(500000.0)
+	expected := []float64{10.0, 2.0}
+	for i, level := range quiet.Levels {
+		if math.Abs(level.RelativeIntensity-expected[i]) > 1e-9 {
+			t.Errorf("level[%d].RelativeIntensity = %v, expected %v", i, level.RelativeIntensity, expected[i])
+		}
+	}
+	if quiet.Levels[0].Intensity != 100.0 {
+		t.Errorf("Intensity = %v, expected snapshot-relative 100 to be untouched", quiet.Levels[0].Intensity)
+	}
+
+	// Busier than the reference exceeds 100
+	quiet.RelativeIntensity(25000.0)
+	if quiet.Levels[0].RelativeIntensity != 200.0 {
+		t.Errorf("RelativeIntensity against a quiet reference = %v, expected 200", quiet.Levels[0].RelativeIntensity)
+	}
+
+	quiet.RelativeIntensity(0)
+	if quiet.Levels[0].RelativeIntensity != 0 {
+		t.Errorf("RelativeIntensity with zero reference = %v, expected 0", quiet.Levels[0].RelativeIntensity)
+	}
+}
@@ -0,0 +1,491 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// ===========================================
+// LIQUIDATION EVENT ANALYTICS
+// ===========================================
+
+// EstimatedInsuranceImpact estimates the bankruptcy shortfall the insurance
+// fund absorbs when events are filled at markPrice instead of their
+// liquidation price. Long liquidations lose (Price - markPrice) * Quantity and
+// short liquidations lose (markPrice - Price) * Quantity; a negative result
+// means the fills left a surplus for the fund
+func EstimatedInsuranceImpact(events []LiquidationEvent, markPrice float64) float64 {
+	if markPrice <= 0 {
+		return 0
+	}
+
+	var impact float64
+	for i := range events {
+		event := &events[i]
+		if event.Price <= 0 || event.Quantity <= 0 {
+			continue
+		}
+		if event.GetLiquidationType() == "LONG" {
+			impact += (event.Price - markPrice) * event.Quantity
+		} else {
+			impact += (markPrice - event.Price) * event.Quantity
+		}
+	}
+
+	return impact
+}
+
+// NotionalUSD returns the USD value of the event, falling back to
+// Price * Quantity when Value is missing
+func (l *LiquidationEvent) NotionalUSD() float64 {
+	if l.Value > 0 {
+		return l.Value
+	}
+	if l.Price > 0 && l.Quantity > 0 {
+		return l.Price * l.Quantity
+	}
+	return 0
+}
+
+// EnsureValue populates a missing Value with Price * Quantity and returns
+// it. An event that already has a Value is left unchanged
+func (l *LiquidationEvent) EnsureValue() float64 {
+	return l.EnsureValueWithMultiplier(1)
+}
+
+// EnsureValueWithMultiplier populates a missing Value using a contract
+// multiplier: Price * Quantity * mult for linear contracts, or Quantity * mult
+// for inverse contracts where mult is the USD face value per contract. A
+// non-positive mult is treated as 1. An event that already has a Value, or
+// lacks a positive Price and Quantity, is left unchanged
+func (l *LiquidationEvent) EnsureValueWithMultiplier(mult float64) float64 {
+	if l.Value != 0 || l.Price <= 0 || l.Quantity <= 0 {
+		return l.Value
+	}
+	if mult <= 0 {
+		mult = 1
+	}
+
+	if l.ContractType == ContractInverse {
+		l.Value = l.Quantity * mult
+	} else {
+		l.Value = l.Price * l.Quantity * mult
+	}
+	return l.Value
+}
+
+// ExchangeVolumeShare returns each exchange's fraction of total liquidation
+// notional in events. The shares sum to 1; an empty or zero-volume batch
+// yields an empty map
+func ExchangeVolumeShare(events []LiquidationEvent) map[Exchange]float64 {
+	volumes := make(map[Exchange]float64)
+	var total float64
+	for i := range events {
+		notional := events[i].NotionalUSD()
+		if notional <= 0 {
+			continue
+		}
+		volumes[events[i].Exchange] += notional
+		total += notional
+	}
+
+	shares := make(map[Exchange]float64, len(volumes))
+	if total <= 0 {
+		return shares
+	}
+	for exchange, volume := range volumes {
+		shares[exchange] = volume / total
+	}
+	return shares
+}
+
+// LiquidationTypeRatioSeries returns, per interval bucket start, the fraction
+// of liquidation notional that came from long liquidations
+func LiquidationTypeRatioSeries(events []LiquidationEvent, interval Interval) map[int64]float64 {
+	longs := make(map[int64]float64)
+	totals := make(map[int64]float64)
+	for i := range events {
+		notional := events[i].NotionalUSD()
+		if notional <= 0 {
+			continue
+		}
+		bucket := RoundToInterval(events[i].Timestamp, interval)
+		totals[bucket] += notional
+		if events[i].GetLiquidationType() == "LONG" {
+			longs[bucket] += notional
+		}
+	}
+
+	series := make(map[int64]float64, len(totals))
+	for bucket, total := range totals {
+		series[bucket] = longs[bucket] / total
+	}
+	return series
+}
+
+// AdaptiveBuckets groups events into variable-width price buckets that each
+// hold roughly targetPerBucket events' worth of volume, so dense regions get
+// finer buckets than sparse ones. Events at the same price never straddle a
+// bucket boundary. Each level's Price is the midpoint of its PriceLow and
+// PriceHigh range
+func AdaptiveBuckets(events []LiquidationEvent, targetPerBucket int) []LiquidationLevel {
+	if targetPerBucket <= 0 {
+		targetPerBucket = 1
+	}
+
+	valid := make([]LiquidationEvent, 0, len(events))
+	var total float64
+	for i := range events {
+		if events[i].Price <= 0 || events[i].NotionalUSD() <= 0 {
+			continue
+		}
+		valid = append(valid, events[i])
+		total += events[i].NotionalUSD()
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Price < valid[j].Price })
+
+	perBucket := total / float64(len(valid)) * float64(targetPerBucket)
+
+	var levels []LiquidationLevel
+	var current *LiquidationLevel
+	var maxVolume float64
+	for i := range valid {
+		event := &valid[i]
+		if current == nil {
+			current = &LiquidationLevel{PriceLow: event.Price}
+		}
+
+		notional := event.NotionalUSD()
+		if event.GetLiquidationType() == "LONG" {
+			current.LongLiquidations += notional
+		} else {
+			current.ShortLiquidations += notional
+		}
+		current.TotalVolume += notional
+		current.PriceHigh = event.Price
+		if event.Timestamp > current.Timestamp {
+			current.Timestamp = event.Timestamp
+		}
+
+		last := i == len(valid)-1
+		if last || (current.TotalVolume >= perBucket && valid[i+1].Price != event.Price) {
+			current.Price = (current.PriceLow + current.PriceHigh) / 2
+			maxVolume = math.Max(maxVolume, current.TotalVolume)
+			levels = append(levels, *current)
+			current = nil
+		}
+	}
+
+	for i := range levels {
+		levels[i].CalculateIntensity(maxVolume)
+	}
+	return levels
+}
+
+// AsMarketOrder returns the market order the liquidation engine sends to the
+// book: long liquidations sell and short liquidations buy
+func (l *LiquidationEvent) AsMarketOrder() (side Side, quantity float64) {
+	if l.GetLiquidationType() == "LONG" {
+		return SideSell, l.Quantity
+	}
+	return SideBuy, l.Quantity
+}
+
+// DeduplicateAcrossExchanges collapses mirrored events that aggregator feeds
+// republish under different exchange tags. Two events are mirrors when they
+// share symbol and liquidation side, come from different exchanges, lie within
+// timeToleranceMs of each other, and their price and quantity differ by at
+// most priceTolerancePct percent. The first event of each mirror group is
+// kept, preserving input order.
+//
+// Genuinely separate liquidations of the same size at the same moment on two
+// venues are indistinguishable from mirrors under this rule and will also be
+// collapsed, so keep the tolerances tight.
+func DeduplicateAcrossExchanges(events []LiquidationEvent, timeToleranceMs int64, priceTolerancePct float64) []LiquidationEvent {
+	within := func(a, b float64) bool {
+		ref := math.Max(math.Abs(a), math.Abs(b))
+		if ref == 0 {
+			return true
+		}
+		return math.Abs(a-b)/ref*100 <= priceTolerancePct
+	}
+
+	result := make([]LiquidationEvent, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		mirrored := false
+		for j := range result {
+			kept := &result[j]
+			if kept.Exchange == event.Exchange || kept.Symbol != event.Symbol ||
+				kept.GetLiquidationType() != event.GetLiquidationType() {
+				continue
+			}
+			dt := kept.Timestamp - event.Timestamp
+			if dt < 0 {
+				dt = -dt
+			}
+			if dt <= timeToleranceMs && within(kept.Price, event.Price) && within(kept.Quantity, event.Quantity) {
+				mirrored = true
+				break
+			}
+		}
+		if !mirrored {
+			result = append(result, *event)
+		}
+	}
+	return result
+}
+
+// ClusteringCoefficient measures how concentrated liquidation prices are, from
+// 0 (volume spread evenly across the price range) to 1 (all volume at one
+// price). Volumes are bucketed by bucketSize across every bucket between the
+// lowest and highest price, including empty ones, and the result is the
+// normalized Gini coefficient of those bucket volumes. Fewer than two events
+// yield 0
+func ClusteringCoefficient(events []LiquidationEvent, bucketSize float64) float64 {
+	if bucketSize <= 0 {
+		return 0
+	}
+
+	volumes := make(map[int64]float64)
+	var count int
+	minBucket, maxBucket := int64(math.MaxInt64), int64(math.MinInt64)
+	for i := range events {
+		notional := events[i].NotionalUSD()
+		if events[i].Price <= 0 || notional <= 0 {
+			continue
+		}
+		bucket := int64(math.Floor(events[i].Price / bucketSize))
+		volumes[bucket] += notional
+		count++
+		if bucket < minBucket {
+			minBucket = bucket
+		}
+		if bucket > maxBucket {
+			maxBucket = bucket
+		}
+	}
+	if count < 2 {
+		return 0
+	}
+
+	n := maxBucket - minBucket + 1
+	if n == 1 {
+		return 1
+	}
+
+	// Empty buckets sort first; only occupied ones contribute to the sum
+	occupied := make([]float64, 0, len(volumes))
+	var total float64
+	for _, v := range volumes {
+		occupied = append(occupied, v)
+		total += v
+	}
+	sort.Float64s(occupied)
+
+	empty := n - int64(len(occupied))
+	var weighted float64
+	for i, v := range occupied {
+		weighted += float64(empty+int64(i)+1) * v
+	}
+
+	nf := float64(n)
+	gini := 2*weighted/(nf*total) - (nf+1)/nf
+	return gini * nf / (nf - 1)
+}
+
+// LiquidationAcceleration returns the second difference of a series of
+// per-interval liquidation totals: element i is the change in liquidation
+// rate between intervals i+1 and i+2. Positive values mean liquidations are
+// speeding up. Series shorter than three points yield nil
+func LiquidationAcceleration(totals []float64) []float64 {
+	if len(totals) < 3 {
+		return nil
+	}
+	acceleration := make([]float64, len(totals)-2)
+	for i := range acceleration {
+		acceleration[i] = totals[i+2] - 2*totals[i+1] + totals[i]
+	}
+	return acceleration
+}
+
+// LeverageTiers are the standard leverage settings, sorted ascending, that
+// GetLeverageBucket snaps estimates to
+var LeverageTiers = []int{5, 10, 20, 25, 50, 75, 100, 125}
+
+// GetLeverageBucket snaps the estimated leverage to the nearest tier in
+// LeverageTiers by absolute distance, preferring the lower tier on a tie.
+// Estimates above the top tier clamp to it; 0 is returned when leverage
+// can't be estimated
+func (l *LiquidationEvent) GetLeverageBucket(markPrice float64) int {
+	estimate := l.GetEstimatedLeverage(markPrice)
+	if estimate <= 0 || math.IsNaN(estimate) || math.IsInf(estimate, 0) || len(LeverageTiers) == 0 {
+		return 0
+	}
+
+	best := LeverageTiers[0]
+	for _, tier := range LeverageTiers[1:] {
+		if math.Abs(float64(tier)-estimate) < math.Abs(float64(best)-estimate) {
+			best = tier
+		}
+	}
+	return best
+}
+
+// CheckMonotonic returns the indices of events whose timestamp is more than
+// maxBackwardMs behind the largest timestamp seen so far in the stream
+func CheckMonotonic(events []LiquidationEvent, maxBackwardMs int64) []int {
+	var violations []int
+	var runningMax int64
+	for i, event := range events {
+		if i > 0 && runningMax-event.Timestamp > maxBackwardMs {
+			violations = append(violations, i)
+		}
+		if i == 0 || event.Timestamp > runningMax {
+			runningMax = event.Timestamp
+		}
+	}
+	return violations
+}
+
+// QuantityInBase returns the position size in base asset units so linear
+// and inverse contracts can be summed together. Inverse contracts are
+// converted at price, falling back to the event price when price is not
+// positive
+func (l *LiquidationEvent) QuantityInBase(price float64) float64 {
+	size := l.ContractSize
+	if size <= 0 {
+		size = 1
+	}
+	if l.ContractType != ContractInverse {
+		return l.Quantity * size
+	}
+
+	if price <= 0 {
+		price = l.Price
+	}
+	if price <= 0 {
+		return 0
+	}
+	return l.Quantity * size / price
+}
+
+// LiquidationStats summarizes a batch of liquidation events
+type LiquidationStats struct {
+	LongVolume     float64 `json:"long_volume"`  // USD
+	ShortVolume    float64 `json:"short_volume"` // USD
+	Count          int     `json:"count"`
+	LongCount      int     `json:"long_count"`
+	ShortCount     int     `json:"short_count"`
+	MaxEventValue  float64 `json:"max_event_value"`  // USD
+	LongShortRatio float64 `json:"long_short_ratio"` // LongVolume / ShortVolume, 0 without short volume
+}
+
+// AggregateLiquidations computes LiquidationStats over events, classifying
+// each with GetLiquidationType and valuing it with NotionalUSD so events
+// missing Value fall back to Price * Quantity. Events are not modified
+func AggregateLiquidations(events []LiquidationEvent) LiquidationStats {
+	var stats LiquidationStats
+	for i := range events {
+		value := events[i].NotionalUSD()
+		if events[i].GetLiquidationType() == "LONG" {
+			stats.LongVolume += value
+			stats.LongCount++
+		} else {
+			stats.ShortVolume += value
+			stats.ShortCount++
+		}
+		stats.Count++
+		stats.MaxEventValue = math.Max(stats.MaxEventValue, value)
+	}
+
+	if stats.ShortVolume > 0 {
+		stats.LongShortRatio = stats.LongVolume / stats.ShortVolume
+	}
+	return stats
+}
+
+// Cascade is a burst of same-side liquidations on one symbol
+type Cascade struct {
+	Symbol      Symbol  `json:"symbol"`
+	Side        Side    `json:"side"` // SideLong or SideShort positions liquidated
+	StartTime   int64   `json:"start_time"`
+	EndTime     int64   `json:"end_time"`
+	EventCount  int     `json:"event_count"`
+	TotalVolume float64 `json:"total_volume"` // USD
+}
+
+// DetectCascades groups events by symbol and liquidated side, sorts each group
+// by timestamp, and slides a windowMs window over it. Every window holding at
+// least minEvents events and minVolumeUSD of notional qualifies, and
+// qualifying windows that share events merge into one cascade. Cascades are
+// returned ordered by start time
+func DetectCascades(events []LiquidationEvent, windowMs int64, minEvents int, minVolumeUSD float64) []Cascade {
+	type cascadeKey struct {
+		symbol Symbol
+		side   Side
+	}
+	groups := make(map[cascadeKey][]LiquidationEvent)
+	var keys []cascadeKey
+	for _, event := range events {
+		key := cascadeKey{event.Symbol, SideShort}
+		if event.GetLiquidationType() == "LONG" {
+			key.side = SideLong
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], event)
+	}
+
+	var cascades []Cascade
+	for _, key := range keys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Timestamp < group[j].Timestamp })
+
+		// Merge qualifying windows [start, end] that share events
+		start, end := -1, -1
+		emit := func() {
+			if start < 0 {
+				return
+			}
+			cascade := Cascade{
+				Symbol:     key.symbol,
+				Side:       key.side,
+				StartTime:  group[start].Timestamp,
+				EndTime:    group[end].Timestamp,
+				EventCount: end - start + 1,
+			}
+			for _, event := range group[start : end+1] {
+				cascade.TotalVolume += event.NotionalUSD()
+			}
+			cascades = append(cascades, cascade)
+		}
+
+		left := 0
+		var volume float64
+		for right := range group {
+			volume += group[right].NotionalUSD()
+			for group[right].Timestamp-group[left].Timestamp > windowMs {
+				volume -= group[left].NotionalUSD()
+				left++
+			}
+			if right-left+1 < minEvents || volume < minVolumeUSD {
+				continue
+			}
+			if start >= 0 && left <= end {
+				end = right
+				continue
+			}
+			emit()
+			start, end = left, right
+		}
+		emit()
+	}
+
+	sort.SliceStable(cascades, func(i, j int) bool { return cascades[i].StartTime < cascades[j].StartTime })
+	return cascades
+}
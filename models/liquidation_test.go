@@ -0,0 +1,497 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEstimatedInsuranceImpact(t *testing.T) {
+	events := []LiquidationEvent{
+		{Side: SideSell, Price: 45000.0, Quantity: 2.0}, // long filled $500 below
+		{Side: SideBuy, Price: 44000.0, Quantity: 1.0},  // short filled $500 above
+		{Side: SideSell, Price: 44000.0, Quantity: 1.0}, // long filled $500 above, surplus
+	}
+
+	tests := []struct {
+		name      string
+		events    []LiquidationEvent
+		markPrice float64
+		expected  float64
+	}{
+		{
+			name:      "mixed shortfall and surplus",
+			events:    events,
+			markPrice: 44500.0,
+			expected:  1000.0 + 500.0 - 500.0,
+		},
+		{
+			name:      "missing mark price",
+			events:    events,
+			markPrice: 0,
+			expected:  0,
+		},
+		{
+			name:      "no events",
+			markPrice: 44500.0,
+			expected:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EstimatedInsuranceImpact(tt.events, tt.markPrice)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("EstimatedInsuranceImpact() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNotionalUSD(t *testing.T) {
+	withValue := LiquidationEvent{Price: 45000.0, Quantity: 1.0, Value: 45100.0}
+	if result := withValue.NotionalUSD(); result != 45100.0 {
+		t.Errorf("NotionalUSD() = %v, expected 45100", result)
+	}
+
+	withoutValue := LiquidationEvent{Price: 45000.0, Quantity: 2.0}
+	if result := withoutValue.NotionalUSD(); result != 90000.0 {
+		t.Errorf("NotionalUSD() without value = %v, expected 90000", result)
+	}
+}
+
+func TestExchangeVolumeShare(t *testing.T) {
+	events := []LiquidationEvent{
+		{Exchange: ExchangeBinance, Value: 50000.0},
+		{Exchange: ExchangeBinance, Price: 100.0, Quantity: 100.0}, // 10000 from price*qty
+		{Exchange: ExchangeOKX, Value: 30000.0},
+		{Exchange: ExchangeBybit, Value: 10000.0},
+	}
+
+	shares := ExchangeVolumeShare(events)
+	expected := map[Exchange]float64{
+		ExchangeBinance: 0.6,
+		ExchangeOKX:     0.3,
+		ExchangeBybit:   0.1,
+	}
+
+	if len(shares) != len(expected) {
+		t.Fatalf("ExchangeVolumeShare() returned %d exchanges, expected %d", len(shares), len(expected))
+	}
+	var sum float64
+	for exchange, want := range expected {
+		if math.Abs(shares[exchange]-want) > 1e-9 {
+			t.Errorf("share[%s] = %v, expected %v", exchange, shares[exchange], want)
+		}
+		sum += shares[exchange]
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("shares sum to %v, expected 1", sum)
+	}
+
+	if shares := ExchangeVolumeShare(nil); len(shares) != 0 {
+		t.Errorf("ExchangeVolumeShare(nil) = %v, expected empty", shares)
+	}
+	if shares := ExchangeVolumeShare([]LiquidationEvent{{Exchange: ExchangeOKX}}); len(shares) != 0 {
+		t.Errorf("ExchangeVolumeShare() with zero volume = %v, expected empty", shares)
+	}
+}
+
+func TestLiquidationTypeRatioSeries(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).UnixMilli()
+	next := base + time.Minute.Milliseconds()
+
+	events := []LiquidationEvent{
+		{Timestamp: base + 1000, Side: SideSell, Value: 30000.0}, // long
+		{Timestamp: base + 2000, Side: SideBuy, Value: 10000.0},  // short
+		{Timestamp: next + 5000, Side: SideBuy, Value: 20000.0},
+		{Timestamp: next + 6000, Side: SideLong, Price: 100.0, Quantity: 50.0}, // 5000 notional
+	}
+
+	series := LiquidationTypeRatioSeries(events, Interval1m)
+	expected := map[int64]float64{
+		base: 0.75,
+		next: 0.2,
+	}
+
+	if len(series) != len(expected) {
+		t.Fatalf("LiquidationTypeRatioSeries() returned %d buckets, expected %d", len(series), len(expected))
+	}
+	for bucket, want := range expected {
+		if math.Abs(series[bucket]-want) > 1e-9 {
+			t.Errorf("ratio[%v] = %v, expected %v", time.UnixMilli(bucket), series[bucket], want)
+		}
+	}
+}
+
+func TestAdaptiveBuckets(t *testing.T) {
+	var events []LiquidationEvent
+	// Dense cluster: 8 events within $8
+	for i := 0; i < 8; i++ {
+		events = append(events, LiquidationEvent{Side: SideSell, Price: 44000.0 + float64(i), Value: 10000.0})
+	}
+	// Sparse tail: 4 events spread over $8000
+	for _, price := range []float64{40000.0, 42000.0, 46000.0, 48000.0} {
+		events = append(events, LiquidationEvent{Side: SideBuy, Price: price, Value: 10000.0})
+	}
+
+	levels := AdaptiveBuckets(events, 2)
+	if len(levels) != 6 {
+		t.Fatalf("AdaptiveBuckets() returned %d levels, expected 6", len(levels))
+	}
+
+	var total float64
+	var denseWidth, sparseWidth float64
+	for i, level := range levels {
+		if level.PriceLow > level.PriceHigh {
+			t.Errorf("level[%d] has inverted range %v-%v", i, level.PriceLow, level.PriceHigh)
+		}
+		if i > 0 && level.PriceLow <= levels[i-1].PriceHigh {
+			t.Errorf("level[%d] overlaps the previous bucket", i)
+		}
+		if level.Price != (level.PriceLow+level.PriceHigh)/2 {
+			t.Errorf("level[%d] Price = %v, expected range midpoint", i, level.Price)
+		}
+		width := level.PriceHigh - level.PriceLow
+		if level.PriceLow >= 44000.0 && level.PriceHigh <= 44007.0 {
+			denseWidth = math.Max(denseWidth, width)
+		} else {
+			sparseWidth = math.Max(sparseWidth, width)
+		}
+		total += level.TotalVolume
+	}
+
+	if denseWidth >= sparseWidth {
+		t.Errorf("dense bucket width %v should be finer than sparse width %v", denseWidth, sparseWidth)
+	}
+	if total != 120000.0 {
+		t.Errorf("total bucketed volume = %v, expected 120000", total)
+	}
+
+	if levels := AdaptiveBuckets(nil, 2); levels != nil {
+		t.Errorf("AdaptiveBuckets(nil) = %v, expected nil", levels)
+	}
+}
+
+func TestAsMarketOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		side     Side
+		expected Side
+	}{
+		{name: "long liquidation sells", side: SideLong, expected: SideSell},
+		{name: "SELL wire side", side: SideSell, expected: SideSell},
+		{name: "short liquidation buys", side: SideShort, expected: SideBuy},
+		{name: "BUY wire side", side: SideBuy, expected: SideBuy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := LiquidationEvent{Side: tt.side, Quantity: 1.5}
+			side, quantity := event.AsMarketOrder()
+			if side != tt.expected {
+				t.Errorf("AsMarketOrder() side = %v, expected %v", side, tt.expected)
+			}
+			if quantity != 1.5 {
+				t.Errorf("AsMarketOrder() quantity = %v, expected 1.5", quantity)
+			}
+		})
+	}
+}
+
+func TestDeduplicateAcrossExchanges(t *testing.T) {
+	base := LiquidationEvent{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: 1700000000000,
+		Side:      SideSell,
+		Price:     45000.0,
+		Quantity:  2.0,
+	}
+	with := func(modify func(*LiquidationEvent)) LiquidationEvent {
+		e := base
+		modify(&e)
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		events   []LiquidationEvent
+		expected int
+	}{
+		{
+			name: "true mirror collapsed",
+			events: []LiquidationEvent{
+				base,
+				with(func(e *LiquidationEvent) { e.Exchange = ExchangeOKX; e.Timestamp += 50; e.Price = 45001.0 }),
+			},
+			expected: 1,
+		},
+		{
+			name: "same exchange kept",
+			events: []LiquidationEvent{
+				base,
+				with(func(e *LiquidationEvent) { e.Timestamp += 10 }),
+			},
+			expected: 2,
+		},
+		{
+			name: "coincidental but distinct size",
+			events: []LiquidationEvent{
+				base,
+				with(func(e *LiquidationEvent) { e.Exchange = ExchangeOKX; e.Quantity = 3.0 }),
+			},
+			expected: 2,
+		},
+		{
+			name: "outside time tolerance",
+			events: []LiquidationEvent{
+				base,
+				with(func(e *LiquidationEvent) { e.Exchange = ExchangeOKX; e.Timestamp += 5000 }),
+			},
+			expected: 2,
+		},
+		{
+			name: "opposite side",
+			events: []LiquidationEvent{
+				base,
+				with(func(e *LiquidationEvent) { e.Exchange = ExchangeBybit; e.Side = SideBuy }),
+			},
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DeduplicateAcrossExchanges(tt.events, 100, 0.01)
+			if len(result) != tt.expected {
+				t.Errorf("DeduplicateAcrossExchanges() returned %d events, expected %d", len(result), tt.expected)
+			}
+			if result[0] != tt.events[0] {
+				t.Errorf("first event should be kept, got %+v", result[0])
+			}
+		})
+	}
+}
+
+func TestClusteringCoefficient(t *testing.T) {
+	var even []LiquidationEvent
+	for i := 0; i < 20; i++ {
+		even = append(even, LiquidationEvent{Price: 44000.0 + float64(i)*100, Value: 10000.0})
+	}
+
+	var clustered []LiquidationEvent
+	for i := 0; i < 10; i++ {
+		clustered = append(clustered,
+			LiquidationEvent{Price: 44000.0 + float64(i), Value: 10000.0},
+			LiquidationEvent{Price: 46000.0 + float64(i), Value: 10000.0},
+		)
+	}
+
+	evenScore := ClusteringCoefficient(even, 100.0)
+	clusteredScore := ClusteringCoefficient(clustered, 100.0)
+
+	if math.Abs(evenScore) > 1e-9 {
+		t.Errorf("evenly spread ClusteringCoefficient() = %v, expected 0", evenScore)
+	}
+	if clusteredScore < 0.9 || clusteredScore > 1 {
+		t.Errorf("clustered ClusteringCoefficient() = %v, expected above 0.9", clusteredScore)
+	}
+
+	samePrice := []LiquidationEvent{{Price: 45000.0, Value: 1.0}, {Price: 45000.0, Value: 2.0}}
+	if result := ClusteringCoefficient(samePrice, 100.0); result != 1 {
+		t.Errorf("single-bucket ClusteringCoefficient() = %v, expected 1", result)
+	}
+	if result := ClusteringCoefficient(even[:1], 100.0); result != 0 {
+		t.Errorf("single-event ClusteringCoefficient() = %v, expected 0", result)
+	}
+}
+
+func TestLiquidationAcceleration(t *testing.T) {
+	// Rate rises 10, 20, 30 then falls back 20, 10
+	totals := []float64{10, 20, 40, 70, 90, 100}
+	expected := []float64{10, 10, -10, -10}
+
+	result := LiquidationAcceleration(totals)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("LiquidationAcceleration() = %v, expected %v", result, expected)
+	}
+
+	if result := LiquidationAcceleration([]float64{1, 2}); result != nil {
+		t.Errorf("LiquidationAcceleration() on short series = %v, expected nil", result)
+	}
+}
+
+func TestGetLeverageBucket(t *testing.T) {
+	markPrice := 45000.0
+	// longPriceFor returns the long liquidation price implying the leverage
+	longPriceFor := func(leverage float64) float64 {
+		return markPrice * (1 - 1/leverage + MaintenanceMargins[ExchangeBinance])
+	}
+
+	tests := []struct {
+		name     string
+		event    LiquidationEvent
+		expected int
+	}{
+		{"9.87x snaps to 10x", LiquidationEvent{Exchange: ExchangeBinance, Side: SideSell, Price: longPriceFor(9.87)}, 10},
+		{"63x is nearer 75x than 50x", LiquidationEvent{Exchange: ExchangeBinance, Side: SideSell, Price: longPriceFor(63)}, 75},
+		{"above top tier clamps", LiquidationEvent{Exchange: ExchangeBinance, Side: SideSell, Price: longPriceFor(200)}, 125},
+		{"below bottom tier", LiquidationEvent{Exchange: ExchangeBinance, Side: SideSell, Price: longPriceFor(2)}, 5},
+		{"invalid estimate", LiquidationEvent{Exchange: ExchangeBinance, Side: SideSell, Price: markPrice + 100}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.event.GetLeverageBucket(markPrice); result != tt.expected {
+				t.Errorf("GetLeverageBucket() = %v, expected %v (estimate %v)", result, tt.expected, tt.event.GetEstimatedLeverage(markPrice))
+			}
+		})
+	}
+}
+
+func TestCheckMonotonic(t *testing.T) {
+	events := []LiquidationEvent{
+		{Timestamp: 1000},
+		{Timestamp: 2000},
+		{Timestamp: 1900}, // small reorder within tolerance
+		{Timestamp: 3000},
+		{Timestamp: 1500}, // large backward jump
+		{Timestamp: 2950}, // still within tolerance of the running max
+		{Timestamp: 4000},
+	}
+
+	result := CheckMonotonic(events, 500)
+	expected := []int{4}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CheckMonotonic() = %v, expected %v", result, expected)
+	}
+
+	if result := CheckMonotonic(nil, 500); len(result) != 0 {
+		t.Errorf("CheckMonotonic(nil) = %v, expected empty", result)
+	}
+}
+
+func TestQuantityInBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    LiquidationEvent
+		price    float64
+		expected float64
+	}{
+		{"linear defaults", LiquidationEvent{Price: 45000.0, Quantity: 2.5}, 45000.0, 2.5},
+		{"linear with contract size", LiquidationEvent{ContractType: ContractLinear, ContractSize: 0.001, Price: 45000.0, Quantity: 300}, 45000.0, 0.3},
+		{"inverse $100 contracts", LiquidationEvent{ContractType: ContractInverse, ContractSize: 100, Price: 40000.0, Quantity: 900}, 45000.0, 2.0},
+		{"inverse falls back to event price", LiquidationEvent{ContractType: ContractInverse, ContractSize: 100, Price: 40000.0, Quantity: 800}, 0, 2.0},
+		{"inverse without price", LiquidationEvent{ContractType: ContractInverse, Quantity: 800}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.event.QuantityInBase(tt.price); math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("QuantityInBase() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnsureValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    LiquidationEvent
+		mult     float64
+		expected float64
+	}{
+		{"populates missing value", LiquidationEvent{Price: 45000.0, Quantity: 2.0}, 1, 90000.0},
+		{"already set is a no-op", LiquidationEvent{Price: 45000.0, Quantity: 2.0, Value: 12345.0}, 1, 12345.0},
+		{"linear multiplier", LiquidationEvent{Price: 45000.0, Quantity: 300}, 0.001, 13500.0},
+		{"inverse face value", LiquidationEvent{Price: 45000.0, Quantity: 50, ContractType: ContractInverse}, 100, 5000.0},
+		{"missing quantity", LiquidationEvent{Price: 45000.0}, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			result := event.EnsureValueWithMultiplier(tt.mult)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("EnsureValueWithMultiplier() = %v, expected %v", result, tt.expected)
+			}
+			if event.Value != result {
+				t.Errorf("Value = %v, expected %v", event.Value, result)
+			}
+		})
+	}
+
+	event := LiquidationEvent{Price: 100.0, Quantity: 3.0}
+	if result := event.EnsureValue(); result != 300.0 || event.Value != 300.0 {
+		t.Errorf("EnsureValue() = %v (Value %v), expected 300", result, event.Value)
+	}
+}
+
+func TestAggregateLiquidations(t *testing.T) {
+	events := []LiquidationEvent{
+		{Side: SideSell, Price: 45000.0, Quantity: 1.0, Value: 45000.0},
+		{Side: SideLong, Price: 44000.0, Quantity: 2.0}, // no value, 88000 notional
+		{Side: SideBuy, Price: 46000.0, Quantity: 0.5, Value: 23000.0},
+		{Side: SideSell, Price: 43000.0, Quantity: 0.1, Value: 4300.0},
+		{Side: SideShort, Price: 47000.0, Quantity: 1.0, Value: 47000.0},
+	}
+
+	stats := AggregateLiquidations(events)
+	expected := LiquidationStats{
+		LongVolume:     137300.0,
+		ShortVolume:    70000.0,
+		Count:          5,
+		LongCount:      3,
+		ShortCount:     2,
+		MaxEventValue:  88000.0,
+		LongShortRatio: 137300.0 / 70000.0,
+	}
+	if stats != expected {
+		t.Errorf("AggregateLiquidations() = %+v, expected %+v", stats, expected)
+	}
+	if events[1].Value != 0 {
+		t.Errorf("AggregateLiquidations() modified event Value to %v", events[1].Value)
+	}
+
+	if stats := AggregateLiquidations(nil); stats != (LiquidationStats{}) {
+		t.Errorf("AggregateLiquidations(nil) = %+v, expected zero stats", stats)
+	}
+}
+
+func TestDetectCascades(t *testing.T) {
+	long := func(ts int64, value float64) LiquidationEvent {
+		return LiquidationEvent{Symbol: SymbolBTCUSDT, Timestamp: ts, Side: SideSell, Price: 45000.0, Value: value}
+	}
+
+	events := []LiquidationEvent{
+		// Quiet period: sparse longs
+		long(0, 20000.0),
+		long(30000, 20000.0),
+		// Burst, deliberately out of order, with overlapping windows
+		long(61500, 150000.0),
+		long(60000, 100000.0),
+		long(60500, 120000.0),
+		long(62800, 90000.0),
+		long(63500, 80000.0),
+		// Opposite side and other symbol don't join the burst
+		{Symbol: SymbolBTCUSDT, Timestamp: 61000, Side: SideBuy, Price: 45000.0, Value: 500000.0},
+		{Symbol: SymbolETHUSDT, Timestamp: 61000, Side: SideSell, Price: 2500.0, Value: 500000.0},
+		// Quiet again
+		long(120000, 20000.0),
+	}
+
+	cascades := DetectCascades(events, 2000, 3, 250000.0)
+	expected := []Cascade{
+		{Symbol: SymbolBTCUSDT, Side: SideLong, StartTime: 60000, EndTime: 63500, EventCount: 5, TotalVolume: 540000.0},
+	}
+	if !reflect.DeepEqual(cascades, expected) {
+		t.Errorf("DetectCascades() = %+v, expected %+v", cascades, expected)
+	}
+
+	quiet := []LiquidationEvent{long(0, 20000.0), long(10000, 20000.0), long(20000, 20000.0)}
+	if cascades := DetectCascades(quiet, 2000, 3, 50000.0); len(cascades) != 0 {
+		t.Errorf("DetectCascades() on quiet stream = %+v, expected none", cascades)
+	}
+}
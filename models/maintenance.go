@@ -0,0 +1,51 @@
+package models
+
+import (
+	"sync"
+)
+
+// ===========================================
+// EXCHANGE MAINTENANCE WINDOWS
+// ===========================================
+
+// MaintenanceWindow represents a scheduled exchange outage in Unix millis
+type MaintenanceWindow struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// Contains reports whether ts falls inside the window
+func (w MaintenanceWindow) Contains(ts int64) bool {
+	return ts >= w.Start && ts < w.End
+}
+
+var (
+	maintenanceMu      sync.RWMutex
+	maintenanceWindows = make(map[Exchange][]MaintenanceWindow)
+)
+
+// SetMaintenanceWindows replaces the maintenance schedule for an exchange;
+// passing no windows clears it
+func SetMaintenanceWindows(exchange Exchange, windows ...MaintenanceWindow) {
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+
+	if len(windows) == 0 {
+		delete(maintenanceWindows, exchange)
+		return
+	}
+	maintenanceWindows[exchange] = append([]MaintenanceWindow(nil), windows...)
+}
+
+// IsInMaintenanceWindow reports whether the exchange is in scheduled maintenance at now
+func IsInMaintenanceWindow(exchange Exchange, now int64) bool {
+	maintenanceMu.RLock()
+	defer maintenanceMu.RUnlock()
+
+	for _, w := range maintenanceWindows[exchange] {
+		if w.Contains(now) {
+			return true
+		}
+	}
+	return false
+}
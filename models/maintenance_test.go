@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsInMaintenanceWindow(t *testing.T) {
+	base := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC).UnixMilli()
+	SetMaintenanceWindows(ExchangeKraken, MaintenanceWindow{
+		Start: base,
+		End:   base + time.Hour.Milliseconds(),
+	})
+	defer SetMaintenanceWindows(ExchangeKraken)
+
+	tests := []struct {
+		name     string
+		exchange Exchange
+		now      int64
+		expected bool
+	}{
+		{
+			name:     "inside window",
+			exchange: ExchangeKraken,
+			now:      base + time.Minute.Milliseconds(),
+			expected: true,
+		},
+		{
+			name:     "after window",
+			exchange: ExchangeKraken,
+			now:      base + 2*time.Hour.Milliseconds(),
+			expected: false,
+		},
+		{
+			name:     "exchange without schedule",
+			exchange: ExchangeBinance,
+			now:      base + time.Minute.Milliseconds(),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsInMaintenanceWindow(tt.exchange, tt.now)
+			if result != tt.expected {
+				t.Errorf("IsInMaintenanceWindow() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
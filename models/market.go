@@ -0,0 +1,297 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ===========================================
+// MARKET SNAPSHOT HELPERS
+// ===========================================
+
+// IsStale reports whether the snapshot is older than maxAge at now. When
+// skipMaintenance is set, snapshots from an exchange inside a scheduled
+// maintenance window are never reported stale
+func (m *MarketSnapshot) IsStale(now int64, maxAge time.Duration, skipMaintenance bool) bool {
+	if skipMaintenance && IsInMaintenanceWindow(m.Exchange, now) {
+		return false
+	}
+	return now-m.Timestamp > maxAge.Milliseconds()
+}
+
+// ExpectedFundingPayment returns the funding a position of positionNotional
+// USD on side pays at the next funding: positionNotional * FundingRate for
+// longs and its negation for shorts. Positive values are paid, negative
+// values received. Sides other than SideLong and SideShort return 0
+func (m *MarketSnapshot) ExpectedFundingPayment(positionNotional float64, side Side) float64 {
+	switch side {
+	case SideLong:
+		return positionNotional * m.FundingRate
+	case SideShort:
+		return -positionNotional * m.FundingRate
+	default:
+		return 0
+	}
+}
+
+// TimeToFunding returns the time from now until NextFundingTime, or 0 when it
+// is unset or already past
+func (m *MarketSnapshot) TimeToFunding(now int64) time.Duration {
+	if m.NextFundingTime <= 0 || m.NextFundingTime <= now {
+		return 0
+	}
+	return time.Duration(m.NextFundingTime-now) * time.Millisecond
+}
+
+// IsFundingImminent reports whether the next funding event falls within the
+// given window from now. Unset or past funding times are never imminent
+func (m *MarketSnapshot) IsFundingImminent(now int64, within time.Duration) bool {
+	remaining := m.TimeToFunding(now)
+	return remaining > 0 && remaining <= within
+}
+
+// DefaultFundingBiasThreshold is the funding rate magnitude beyond which
+// FundingBias reports one side as crowded
+const DefaultFundingBiasThreshold = 0.0001 // 0.01% per funding interval
+
+// FundingBias classifies funding at DefaultFundingBiasThreshold
+func (m *MarketSnapshot) FundingBias() string {
+	return m.FundingBiasWithThreshold(DefaultFundingBiasThreshold)
+}
+
+// FundingBiasWithThreshold returns "long-heavy" when FundingRate is above
+// threshold (longs pay shorts, so longs are crowded and more exposed to
+// forced liquidation), "short-heavy" when it is below -threshold, and
+// "neutral" otherwise, including exactly at the threshold
+func (m *MarketSnapshot) FundingBiasWithThreshold(threshold float64) string {
+	switch {
+	case m.FundingRate > threshold:
+		return "long-heavy"
+	case m.FundingRate < -threshold:
+		return "short-heavy"
+	default:
+		return "neutral"
+	}
+}
+
+// OpenInterestDelta returns the change in open interest from prev to curr in
+// contracts and USD, and the percent change in contracts. The percent change
+// is 0 when prev has no open interest. Returns an error if the snapshots are
+// for different exchanges or symbols or curr is not newer than prev
+func OpenInterestDelta(prev, curr MarketSnapshot) (deltaContracts float64, deltaUSD float64, pctChange float64, err error) {
+	if prev.Exchange != curr.Exchange {
+		return 0, 0, 0, fmt.Errorf("exchange mismatch: %s vs %s", prev.Exchange, curr.Exchange)
+	}
+	if prev.Symbol != curr.Symbol {
+		return 0, 0, 0, fmt.Errorf("symbol mismatch: %s vs %s", prev.Symbol, curr.Symbol)
+	}
+	if curr.Timestamp <= prev.Timestamp {
+		return 0, 0, 0, fmt.Errorf("current snapshot at %d is not after previous at %d", curr.Timestamp, prev.Timestamp)
+	}
+
+	deltaContracts = curr.OpenInterest - prev.OpenInterest
+	deltaUSD = curr.OpenInterestUSD - prev.OpenInterestUSD
+	if prev.OpenInterest != 0 {
+		pctChange = deltaContracts / prev.OpenInterest * 100
+	}
+	return deltaContracts, deltaUSD, pctChange, nil
+}
+
+// EstimateLevelsFromOI projects synthetic liquidation levels from open
+// interest when direct liquidation events are sparse.
+//
+// The model assumes open interest is split evenly between longs and shorts
+// opened at the current mark price. leverageDistribution maps a leverage to
+// the fraction of open interest using it (fractions are normalized to sum to
+// 1). A position with leverage L liquidates at
+//
+//	long:  mark * (1 - 1/L + mm)
+//	short: mark * (1 + 1/L - mm)
+//
+// where mm is the snapshot exchange's rate from MaintenanceMargins. Each
+// liquidation price is snapped to the nearest entry in priceBins and the
+// notional accumulated there. Leverages of 1x or less never liquidate and are
+// ignored.
+func EstimateLevelsFromOI(snapshot *MarketSnapshot, priceBins []float64, leverageDistribution map[float64]float64) []LiquidationLevel {
+	if snapshot == nil || snapshot.MarkPrice <= 0 || len(priceBins) == 0 {
+		return nil
+	}
+
+	oiUSD := snapshot.OpenInterestUSD
+	if oiUSD <= 0 {
+		oiUSD = snapshot.OpenInterest * snapshot.MarkPrice
+	}
+	if oiUSD <= 0 {
+		return nil
+	}
+
+	var totalWeight float64
+	for leverage, weight := range leverageDistribution {
+		if leverage > 1 && weight > 0 {
+			totalWeight += weight
+		}
+	}
+	if totalWeight <= 0 {
+		return nil
+	}
+
+	bins := append([]float64(nil), priceBins...)
+	sort.Float64s(bins)
+	nearestBin := func(price float64) int {
+		i := sort.SearchFloat64s(bins, price)
+		if i == len(bins) {
+			return i - 1
+		}
+		if i > 0 && price-bins[i-1] <= bins[i]-price {
+			return i - 1
+		}
+		return i
+	}
+
+	longs := make([]float64, len(bins))
+	shorts := make([]float64, len(bins))
+	mark := snapshot.MarkPrice
+	mm := maintenanceMarginFor(snapshot.Exchange)
+	for leverage, weight := range leverageDistribution {
+		if leverage <= 1 || weight <= 0 {
+			continue
+		}
+		notional := oiUSD / 2 * weight / totalWeight
+		longs[nearestBin(mark*(1-1/leverage+mm))] += notional
+		shorts[nearestBin(mark*(1+1/leverage-mm))] += notional
+	}
+
+	var levels []LiquidationLevel
+	var maxVolume float64
+	for i, price := range bins {
+		total := longs[i] + shorts[i]
+		if total <= 0 {
+			continue
+		}
+		levels = append(levels, LiquidationLevel{
+			Price:             price,
+			LongLiquidations:  longs[i],
+			ShortLiquidations: shorts[i],
+			TotalVolume:       total,
+			Timestamp:         snapshot.Timestamp,
+		})
+		maxVolume = math.Max(maxVolume, total)
+	}
+	for i := range levels {
+		levels[i].CalculateIntensity(maxVolume)
+	}
+
+	return levels
+}
+
+// fundingBiasSaturation is the funding rate at which the funding component of
+// FundingLiquidationBias saturates
+const fundingBiasSaturation = 0.001 // 0.1% per funding interval
+
+// FundingLiquidationBias combines funding with the heatmap's wall layout into
+// a squeeze signal in [-1, 1]:
+//
+//	funding = clamp(FundingRate / 0.001, -1, 1)
+//	walls   = (longBelow - shortAbove) / (longBelow + shortAbove)
+//	bias    = (funding + walls) / 2
+//
+// where longBelow is long-liquidation volume below CurrentPrice and
+// shortAbove is short-liquidation volume above it. Positive values indicate
+// elevated long-squeeze risk, negative values short-squeeze risk. Returns 0
+// when either input is missing or the symbols differ.
+func FundingLiquidationBias(snapshot *MarketSnapshot, h *HeatmapData) float64 {
+	if snapshot == nil || h == nil || snapshot.Symbol != h.Symbol {
+		return 0
+	}
+
+	funding := math.Max(-1, math.Min(1, snapshot.FundingRate/fundingBiasSaturation))
+
+	var longBelow, shortAbove float64
+	for _, level := range h.Levels {
+		if level.Price < h.CurrentPrice {
+			longBelow += level.LongLiquidations
+		} else if level.Price > h.CurrentPrice {
+			shortAbove += level.ShortLiquidations
+		}
+	}
+
+	var walls float64
+	if total := longBelow + shortAbove; total > 0 {
+		walls = (longBelow - shortAbove) / total
+	}
+
+	return (funding + walls) / 2
+}
+
+// ScaleLevelsByOpenInterest returns a copy of levels with OIFraction set to
+// each level's TotalVolume divided by oiUSD, so the same wall reads as large
+// against thin open interest and small against deep open interest. A
+// non-positive oiUSD leaves every fraction at 0
+func ScaleLevelsByOpenInterest(levels []LiquidationLevel, oiUSD float64) []LiquidationLevel {
+	scaled := append([]LiquidationLevel(nil), levels...)
+	for i := range scaled {
+		if oiUSD <= 0 {
+			scaled[i].OIFraction = 0
+			continue
+		}
+		scaled[i].OIFraction = scaled[i].TotalVolume / oiUSD
+	}
+	return scaled
+}
+
+// EnrichedLiquidation is a liquidation event paired with the market snapshot
+// closest to it in time and metrics derived from that snapshot
+type EnrichedLiquidation struct {
+	LiquidationEvent
+	Market                *MarketSnapshot `json:"market,omitempty"`
+	EstimatedLeverage     float64         `json:"estimated_leverage,omitempty"`
+	PercentOfOpenInterest float64         `json:"percent_of_open_interest,omitempty"` // 0-100
+}
+
+// EnrichWithMarket pairs each event with the snapshot of the same exchange
+// and symbol closest to it in time, estimating leverage against the
+// snapshot's mark price and sizing the event against open interest. Events
+// without a matching snapshot are returned with Market unset
+func EnrichWithMarket(events []LiquidationEvent, snapshots []MarketSnapshot) []EnrichedLiquidation {
+	type marketKey struct {
+		exchange Exchange
+		symbol   Symbol
+	}
+	byMarket := make(map[marketKey][]*MarketSnapshot)
+	for i := range snapshots {
+		key := marketKey{snapshots[i].Exchange, snapshots[i].Symbol}
+		byMarket[key] = append(byMarket[key], &snapshots[i])
+	}
+	for _, candidates := range byMarket {
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Timestamp < candidates[j].Timestamp })
+	}
+
+	enriched := make([]EnrichedLiquidation, len(events))
+	for i, event := range events {
+		enriched[i].LiquidationEvent = event
+
+		candidates := byMarket[marketKey{event.Exchange, event.Symbol}]
+		if len(candidates) == 0 {
+			continue
+		}
+		idx := sort.Search(len(candidates), func(j int) bool { return candidates[j].Timestamp >= event.Timestamp })
+		if idx == len(candidates) || (idx > 0 && event.Timestamp-candidates[idx-1].Timestamp <= candidates[idx].Timestamp-event.Timestamp) {
+			idx--
+		}
+
+		market := *candidates[idx]
+		enriched[i].Market = &market
+		enriched[i].EstimatedLeverage = event.GetEstimatedLeverage(market.MarkPrice)
+
+		oiUSD := market.OpenInterestUSD
+		if oiUSD <= 0 {
+			oiUSD = market.OpenInterest * market.MarkPrice
+		}
+		if oiUSD > 0 {
+			enriched[i].PercentOfOpenInterest = event.NotionalUSD() / oiUSD * 100
+		}
+	}
+	return enriched
+}
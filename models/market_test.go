@@ -0,0 +1,398 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMarketSnapshotIsStale(t *testing.T) {
+	base := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC).UnixMilli()
+	SetMaintenanceWindows(ExchangeKraken, MaintenanceWindow{
+		Start: base,
+		End:   base + time.Hour.Milliseconds(),
+	})
+	defer SetMaintenanceWindows(ExchangeKraken)
+
+	tests := []struct {
+		name            string
+		exchange        Exchange
+		now             int64
+		skipMaintenance bool
+		expected        bool
+	}{
+		{
+			name:     "fresh snapshot",
+			exchange: ExchangeBinance,
+			now:      base + 10*time.Second.Milliseconds(),
+			expected: false,
+		},
+		{
+			name:     "stale snapshot",
+			exchange: ExchangeBinance,
+			now:      base + 5*time.Minute.Milliseconds(),
+			expected: true,
+		},
+		{
+			name:            "stale during maintenance is skipped",
+			exchange:        ExchangeKraken,
+			now:             base + 5*time.Minute.Milliseconds(),
+			skipMaintenance: true,
+			expected:        false,
+		},
+		{
+			name:            "stale after maintenance",
+			exchange:        ExchangeKraken,
+			now:             base + 2*time.Hour.Milliseconds(),
+			skipMaintenance: true,
+			expected:        true,
+		},
+		{
+			name:     "maintenance ignored without skip",
+			exchange: ExchangeKraken,
+			now:      base + 5*time.Minute.Milliseconds(),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := MarketSnapshot{Exchange: tt.exchange, Timestamp: base}
+			result := snapshot.IsStale(tt.now, time.Minute, tt.skipMaintenance)
+			if result != tt.expected {
+				t.Errorf("IsStale() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEstimateLevelsFromOI(t *testing.T) {
+	snapshot := &MarketSnapshot{
+		Exchange:        ExchangeBinance,
+		Symbol:          SymbolBTCUSDT,
+		Timestamp:       1234567890,
+		MarkPrice:       40000.0,
+		OpenInterestUSD: 1000000.0,
+	}
+
+	var bins []float64
+	for price := 30000.0; price <= 50000.0; price += 100.0 {
+		bins = append(bins, price)
+	}
+
+	// 75% of OI at 10x, 25% at 20x
+	levels := EstimateLevelsFromOI(snapshot, bins, map[float64]float64{10: 3, 20: 1})
+	if len(levels) != 4 {
+		t.Fatalf("EstimateLevelsFromOI() returned %d levels, expected 4: %+v", len(levels), levels)
+	}
+
+	// 10x longs liquidate at 40000 * (1 - 0.1 + 0.004) = 36160 -> 36200 bin
+	expected := []LiquidationLevel{
+		{Price: 36200.0, LongLiquidations: 375000.0},
+		{Price: 38200.0, LongLiquidations: 125000.0},
+		{Price: 41800.0, ShortLiquidations: 125000.0},
+		{Price: 43800.0, ShortLiquidations: 375000.0},
+	}
+	for i, want := range expected {
+		got := levels[i]
+		if got.Price != want.Price || got.LongLiquidations != want.LongLiquidations ||
+			got.ShortLiquidations != want.ShortLiquidations {
+			t.Errorf("level[%d] = %+v, expected %+v", i, got, want)
+		}
+		if got.Timestamp != snapshot.Timestamp {
+			t.Errorf("level[%d] Timestamp = %v, expected %v", i, got.Timestamp, snapshot.Timestamp)
+		}
+	}
+	if levels[0].Intensity != 100.0 {
+		t.Errorf("dominant level Intensity = %v, expected 100", levels[0].Intensity)
+	}
+
+	if levels := EstimateLevelsFromOI(&MarketSnapshot{MarkPrice: 40000.0}, bins, map[float64]float64{10: 1}); levels != nil {
+		t.Errorf("EstimateLevelsFromOI() without OI = %v, expected nil", levels)
+	}
+}
+
+func TestFundingLiquidationBias(t *testing.T) {
+	heatmap := func(longBelow, shortAbove float64) *HeatmapData {
+		return &HeatmapData{
+			Symbol:       SymbolBTCUSDT,
+			CurrentPrice: 45000.0,
+			Levels: []LiquidationLevel{
+				{Price: 44000.0, LongLiquidations: longBelow},
+				{Price: 46000.0, ShortLiquidations: shortAbove},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		snapshot *MarketSnapshot
+		heatmap  *HeatmapData
+		expected float64
+	}{
+		{
+			name:     "long squeeze prone",
+			snapshot: &MarketSnapshot{Symbol: SymbolBTCUSDT, FundingRate: 0.002},
+			heatmap:  heatmap(300000.0, 100000.0),
+			expected: 0.75,
+		},
+		{
+			name:     "short squeeze prone",
+			snapshot: &MarketSnapshot{Symbol: SymbolBTCUSDT, FundingRate: -0.0005},
+			heatmap:  heatmap(0, 200000.0),
+			expected: -0.75,
+		},
+		{
+			name:     "mismatched symbols",
+			snapshot: &MarketSnapshot{Symbol: SymbolETHUSDT, FundingRate: 0.002},
+			heatmap:  heatmap(300000.0, 100000.0),
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FundingLiquidationBias(tt.snapshot, tt.heatmap)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("FundingLiquidationBias() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScaleLevelsByOpenInterest(t *testing.T) {
+	levels := []LiquidationLevel{{Price: 44000.0, TotalVolume: 2000000.0}}
+
+	tests := []struct {
+		name     string
+		oiUSD    float64
+		expected float64
+	}{
+		{name: "thin open interest", oiUSD: 50000000.0, expected: 0.04},
+		{name: "deep open interest", oiUSD: 2000000000.0, expected: 0.001},
+		{name: "zero open interest", oiUSD: 0, expected: 0},
+		{name: "negative open interest", oiUSD: -1.0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ScaleLevelsByOpenInterest(levels, tt.oiUSD)
+			if math.Abs(result[0].OIFraction-tt.expected) > 1e-12 {
+				t.Errorf("ScaleLevelsByOpenInterest() = %v, expected %v", result[0].OIFraction, tt.expected)
+			}
+		})
+	}
+
+	if levels[0].OIFraction != 0 {
+		t.Errorf("ScaleLevelsByOpenInterest() modified its input: %v", levels[0].OIFraction)
+	}
+}
+
+func TestExpectedFundingPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		fundingRate float64
+		side        Side
+		expected    float64
+	}{
+		{"long pays positive funding", 0.0001, SideLong, 10.0},
+		{"short receives positive funding", 0.0001, SideShort, -10.0},
+		{"long receives negative funding", -0.0003, SideLong, -30.0},
+		{"short pays negative funding", -0.0003, SideShort, 30.0},
+		{"unknown side", 0.0001, "sideways", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := MarketSnapshot{FundingRate: tt.fundingRate}
+			result := snapshot.ExpectedFundingPayment(100000.0, tt.side)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("ExpectedFundingPayment() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeToFunding(t *testing.T) {
+	now := int64(1700000000000)
+
+	tests := []struct {
+		name             string
+		nextFunding      int64
+		expectedDuration time.Duration
+		expectedImminent bool
+	}{
+		{name: "thirty seconds out", nextFunding: now + 30000, expectedDuration: 30 * time.Second, expectedImminent: true},
+		{name: "an hour out", nextFunding: now + time.Hour.Milliseconds(), expectedDuration: time.Hour, expectedImminent: false},
+		{name: "already past", nextFunding: now - 30000, expectedDuration: 0, expectedImminent: false},
+		{name: "unset", nextFunding: 0, expectedDuration: 0, expectedImminent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := MarketSnapshot{NextFundingTime: tt.nextFunding}
+			if result := snapshot.TimeToFunding(now); result != tt.expectedDuration {
+				t.Errorf("TimeToFunding() = %v, expected %v", result, tt.expectedDuration)
+			}
+			if result := snapshot.IsFundingImminent(now, time.Minute); result != tt.expectedImminent {
+				t.Errorf("IsFundingImminent() = %v, expected %v", result, tt.expectedImminent)
+			}
+		})
+	}
+}
+
+func TestOpenInterestDelta(t *testing.T) {
+	prev := MarketSnapshot{
+		Exchange:        ExchangeBinance,
+		Symbol:          SymbolBTCUSDT,
+		Timestamp:       1000,
+		OpenInterest:    10000.0,
+		OpenInterestUSD: 450000000.0,
+	}
+	snapshot := func(timestamp int64, oi, oiUSD float64) MarketSnapshot {
+		s := prev
+		s.Timestamp, s.OpenInterest, s.OpenInterestUSD = timestamp, oi, oiUSD
+		return s
+	}
+
+	tests := []struct {
+		name              string
+		prev              MarketSnapshot
+		curr              MarketSnapshot
+		expectedContracts float64
+		expectedUSD       float64
+		expectedPct       float64
+		wantErr           bool
+	}{
+		{
+			name:              "increase",
+			prev:              prev,
+			curr:              snapshot(2000, 11000.0, 500000000.0),
+			expectedContracts: 1000.0,
+			expectedUSD:       50000000.0,
+			expectedPct:       10.0,
+		},
+		{
+			name:              "decrease",
+			prev:              prev,
+			curr:              snapshot(2000, 7500.0, 330000000.0),
+			expectedContracts: -2500.0,
+			expectedUSD:       -120000000.0,
+			expectedPct:       -25.0,
+		},
+		{
+			name:              "zero previous open interest",
+			prev:              snapshot(1000, 0, 0),
+			curr:              snapshot(2000, 500.0, 22500000.0),
+			expectedContracts: 500.0,
+			expectedUSD:       22500000.0,
+			expectedPct:       0,
+		},
+		{
+			name:    "mismatched symbol",
+			prev:    prev,
+			curr:    MarketSnapshot{Exchange: ExchangeBinance, Symbol: SymbolETHUSDT, Timestamp: 2000},
+			wantErr: true,
+		},
+		{
+			name:    "not newer",
+			prev:    prev,
+			curr:    snapshot(1000, 11000.0, 500000000.0),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contracts, usd, pct, err := OpenInterestDelta(tt.prev, tt.curr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OpenInterestDelta() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if contracts != tt.expectedContracts || usd != tt.expectedUSD || math.Abs(pct-tt.expectedPct) > 1e-9 {
+				t.Errorf("OpenInterestDelta() = %v, %v, %v, expected %v, %v, %v",
+					contracts, usd, pct, tt.expectedContracts, tt.expectedUSD, tt.expectedPct)
+			}
+		})
+	}
+}
+
+func TestFundingBias(t *testing.T) {
+	tests := []struct {
+		name     string
+		funding  float64
+		expected string
+	}{
+		{name: "crowded longs", funding: 0.0005, expected: "long-heavy"},
+		{name: "crowded shorts", funding: -0.0003, expected: "short-heavy"},
+		{name: "near zero", funding: 0.00002, expected: "neutral"},
+		{name: "at positive threshold", funding: DefaultFundingBiasThreshold, expected: "neutral"},
+		{name: "at negative threshold", funding: -DefaultFundingBiasThreshold, expected: "neutral"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := MarketSnapshot{FundingRate: tt.funding}
+			if result := snapshot.FundingBias(); result != tt.expected {
+				t.Errorf("FundingBias() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	snapshot := MarketSnapshot{FundingRate: 0.0005}
+	if result := snapshot.FundingBiasWithThreshold(0.001); result != "neutral" {
+		t.Errorf("FundingBiasWithThreshold() = %v, expected neutral", result)
+	}
+}
+
+func TestEnrichWithMarket(t *testing.T) {
+	snapshots := []MarketSnapshot{
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 5000, MarkPrice: 46000.0, OpenInterestUSD: 10000000.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 1000, MarkPrice: 45000.0, OpenInterestUSD: 9000000.0},
+		{Exchange: ExchangeOKX, Symbol: SymbolBTCUSDT, Timestamp: 2000, MarkPrice: 45100.0, OpenInterest: 100.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolETHUSDT, Timestamp: 2000, MarkPrice: 2500.0, OpenInterestUSD: 5000000.0},
+	}
+	events := []LiquidationEvent{
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 2000, Side: SideSell, Price: 40500.0, Value: 90000.0},
+		{Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 4500, Side: SideBuy, Price: 47000.0, Value: 50000.0},
+		{Exchange: ExchangeOKX, Symbol: SymbolBTCUSDT, Timestamp: 9000, Side: SideSell, Price: 44000.0, Value: 45100.0},
+		{Exchange: ExchangeBybit, Symbol: SymbolBTCUSDT, Timestamp: 2000, Side: SideSell, Price: 44000.0, Value: 1000.0},
+	}
+
+	enriched := EnrichWithMarket(events, snapshots)
+	if len(enriched) != len(events) {
+		t.Fatalf("EnrichWithMarket() returned %d events, expected %d", len(enriched), len(events))
+	}
+
+	tests := []struct {
+		name          string
+		snapshotTime  int64
+		percentOfOI   float64
+		wantLeveraged bool
+	}{
+		{"nearest earlier snapshot", 1000, 1.0, true},
+		{"nearest later snapshot", 5000, 0.5, true},
+		{"only snapshot for exchange", 2000, 1.0, true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := enriched[i]
+			if got.Market == nil {
+				t.Fatal("Market = nil, expected a matched snapshot")
+			}
+			if got.Market.Timestamp != tt.snapshotTime || got.Market.Exchange != got.Exchange {
+				t.Errorf("Market = %v@%v, expected %v@%v", got.Market.Exchange, got.Market.Timestamp, got.Exchange, tt.snapshotTime)
+			}
+			if math.Abs(got.PercentOfOpenInterest-tt.percentOfOI) > 1e-9 {
+				t.Errorf("PercentOfOpenInterest = %v, expected %v", got.PercentOfOpenInterest, tt.percentOfOI)
+			}
+			expected := got.LiquidationEvent.GetEstimatedLeverage(got.Market.MarkPrice)
+			if got.EstimatedLeverage != expected || (expected > 0) != tt.wantLeveraged {
+				t.Errorf("EstimatedLeverage = %v, expected %v", got.EstimatedLeverage, expected)
+			}
+		})
+	}
+
+	if unmatched := enriched[3]; unmatched.Market != nil || unmatched.PercentOfOpenInterest != 0 {
+		t.Errorf("unmatched event = %+v, expected no market context", unmatched)
+	}
+}
@@ -2,9 +2,14 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Exchange represents supported exchanges
@@ -40,6 +45,14 @@ const (
 	OrderTypeBankruptcy  OrderType = "bankruptcy"
 )
 
+// ContractType represents how a contract's quantity is denominated
+type ContractType string
+
+const (
+	ContractLinear  ContractType = "linear"  // Quantity in base asset, margined in quote
+	ContractInverse ContractType = "inverse" // Quantity in USD contracts, margined in base
+)
+
 // Side represents position side
 type Side string
 
@@ -61,61 +74,78 @@ const (
 	Interval1h  Interval = "1h"
 	Interval4h  Interval = "4h"
 	Interval1d  Interval = "1d"
+	Interval1w  Interval = "1w"
 )
 
+// WeekStart is the first day of the week used when rounding to Interval1w
+var WeekStart = time.Monday
+
 // ===========================================
 // RAW MARKET DATA STRUCTURES
 // ===========================================
 
 // MarketSnapshot represents current market state
 type MarketSnapshot struct {
-	Exchange        Exchange `json:"exchange"`
-	Symbol          Symbol   `json:"symbol"`
-	Timestamp       int64    `json:"timestamp"`
-	MarkPrice       float64  `json:"mark_price"`
-	IndexPrice      float64  `json:"index_price"`
-	FundingRate     float64  `json:"funding_rate"`
-	OpenInterest    float64  `json:"open_interest"`     // in contracts
-	OpenInterestUSD float64  `json:"open_interest_usd"` // in USD
-	Volume24h       float64  `json:"volume_24h"`        // in USD
-	Turnover24h     float64  `json:"turnover_24h"`      // in USD
-	NextFundingTime int64    `json:"next_funding_time"`
+	Exchange        Exchange `json:"exchange" msgpack:"exchange"`
+	Symbol          Symbol   `json:"symbol" msgpack:"symbol"`
+	Timestamp       int64    `json:"timestamp" msgpack:"timestamp"`
+	MarkPrice       float64  `json:"mark_price" msgpack:"mark_price"`
+	IndexPrice      float64  `json:"index_price" msgpack:"index_price"`
+	FundingRate     float64  `json:"funding_rate" msgpack:"funding_rate"`
+	OpenInterest    float64  `json:"open_interest" msgpack:"open_interest"`         // in contracts
+	OpenInterestUSD float64  `json:"open_interest_usd" msgpack:"open_interest_usd"` // in USD
+	Volume24h       float64  `json:"volume_24h" msgpack:"volume_24h"`               // in USD
+	Turnover24h     float64  `json:"turnover_24h" msgpack:"turnover_24h"`           // in USD
+	NextFundingTime int64    `json:"next_funding_time" msgpack:"next_funding_time"`
 }
 
 // LiquidationEvent represents a single liquidation from exchange
 type LiquidationEvent struct {
-	Exchange       Exchange  `json:"exchange"`
-	Symbol         Symbol    `json:"symbol"`
-	Timestamp      int64     `json:"timestamp"`
-	Side           Side      `json:"side"`     // BUY/SELL or long/short
-	Price          float64   `json:"price"`    // Liquidation price
-	Quantity       float64   `json:"quantity"` // Contract quantity
-	Value          float64   `json:"value"`    // USD value
-	OrderType      OrderType `json:"order_type"`
-	AvgPrice       float64   `json:"avg_price,omitempty"`        // Average fill price
-	FilledQty      float64   `json:"filled_qty,omitempty"`       // Filled quantity
-	OrderStatus    string    `json:"order_status,omitempty"`     // Order status
-	OrderTradeTime int64     `json:"order_trade_time,omitempty"` // Trade execution time
+	Exchange       Exchange     `json:"exchange" msgpack:"exchange"`
+	Symbol         Symbol       `json:"symbol" msgpack:"symbol"`
+	Timestamp      int64        `json:"timestamp" msgpack:"timestamp"`
+	Side           Side         `json:"side" msgpack:"side"`         // BUY/SELL or long/short
+	Price          float64      `json:"price" msgpack:"price"`       // Liquidation price
+	Quantity       float64      `json:"quantity" msgpack:"quantity"` // Contract quantity
+	Value          float64      `json:"value" msgpack:"value"`       // USD value
+	OrderType      OrderType    `json:"order_type" msgpack:"order_type"`
+	AvgPrice       float64      `json:"avg_price,omitempty" msgpack:"avg_price,omitempty"`               // Average fill price
+	FilledQty      float64      `json:"filled_qty,omitempty" msgpack:"filled_qty,omitempty"`             // Filled quantity
+	OrderStatus    string       `json:"order_status,omitempty" msgpack:"order_status,omitempty"`         // Order status
+	OrderTradeTime int64        `json:"order_trade_time,omitempty" msgpack:"order_trade_time,omitempty"` // Trade execution time
+	ContractType   ContractType `json:"contract_type,omitempty" msgpack:"contract_type,omitempty"`       // Linear when empty
+	ContractSize   float64      `json:"contract_size,omitempty" msgpack:"contract_size,omitempty"`       // Base units (linear) or USD (inverse) per contract, 1 when unset
 }
 
 // OrderBookSnapshot represents order book state
 type OrderBookSnapshot struct {
+	Exchange     Exchange     `json:"exchange" msgpack:"exchange"`
+	Symbol       Symbol       `json:"symbol" msgpack:"symbol"`
+	Timestamp    int64        `json:"timestamp" msgpack:"timestamp"`
+	Bids         []PriceLevel `json:"bids" msgpack:"bids"`
+	Asks         []PriceLevel `json:"asks" msgpack:"asks"`
+	LastUpdateID int64        `json:"last_update_id,omitempty" msgpack:"last_update_id,omitempty"`
+	Spread       float64      `json:"spread,omitempty" msgpack:"spread,omitempty"`
+	MidPrice     float64      `json:"mid_price,omitempty" msgpack:"mid_price,omitempty"`
+	Imbalance    float64      `json:"imbalance,omitempty" msgpack:"imbalance,omitempty"` // -1 to 1
+}
+
+// PriceLevel represents a price and size at that level
+type PriceLevel struct {
+	Price    float64 `json:"price" msgpack:"price"`
+	Quantity float64 `json:"quantity" msgpack:"quantity"`
+	Count    int     `json:"count,omitempty" msgpack:"count,omitempty"` // Number of orders at this level
+}
+
+// OrderBookDelta represents an incremental order book update. Levels with a
+// zero quantity remove that price from the book
+type OrderBookDelta struct {
 	Exchange     Exchange     `json:"exchange"`
 	Symbol       Symbol       `json:"symbol"`
 	Timestamp    int64        `json:"timestamp"`
 	Bids         []PriceLevel `json:"bids"`
 	Asks         []PriceLevel `json:"asks"`
-	LastUpdateID int64        `json:"last_update_id,omitempty"`
-	Spread       float64      `json:"spread,omitempty"`
-	MidPrice     float64      `json:"mid_price,omitempty"`
-	Imbalance    float64      `json:"imbalance,omitempty"` // -1 to 1
-}
-
-// PriceLevel represents a price and size at that level
-type PriceLevel struct {
-	Price    float64 `json:"price"`
-	Quantity float64 `json:"quantity"`
-	Count    int     `json:"count,omitempty"` // Number of orders at this level
+	LastUpdateID int64        `json:"last_update_id"`
 }
 
 // ===========================================
@@ -124,56 +154,64 @@ type PriceLevel struct {
 
 // HeatmapData represents the complete liquidation heatmap
 type HeatmapData struct {
-	Symbol       Symbol               `json:"symbol"`
-	Exchange     Exchange             `json:"exchange,omitempty"`
-	Timestamp    int64                `json:"timestamp"`
-	Interval     Interval             `json:"interval"`
-	CurrentPrice float64              `json:"current_price"`
-	Levels       []LiquidationLevel   `json:"levels"`
-	Clusters     []LiquidationCluster `json:"clusters"`
-	Summary      HeatmapSummary       `json:"summary"`
+	Symbol       Symbol               `json:"symbol" msgpack:"symbol"`
+	Exchange     Exchange             `json:"exchange,omitempty" msgpack:"exchange,omitempty"`
+	Timestamp    int64                `json:"timestamp" msgpack:"timestamp"`
+	Interval     Interval             `json:"interval" msgpack:"interval"`
+	CurrentPrice float64              `json:"current_price" msgpack:"current_price"`
+	Levels       []LiquidationLevel   `json:"levels" msgpack:"levels"`
+	Clusters     []LiquidationCluster `json:"clusters" msgpack:"clusters"`
+	Summary      HeatmapSummary       `json:"summary" msgpack:"summary"`
 }
 
 // LiquidationLevel represents liquidations at a specific price
 type LiquidationLevel struct {
-	Price             float64 `json:"price"`
-	LongLiquidations  float64 `json:"long_liquidations"`  // USD volume
-	ShortLiquidations float64 `json:"short_liquidations"` // USD volume
-	TotalVolume       float64 `json:"total_volume"`       // Total USD volume
-	Intensity         float64 `json:"intensity"`          // 0-100 score
-	Timestamp         int64   `json:"timestamp"`
+	Price             float64 `json:"price" msgpack:"price"`
+	LongLiquidations  float64 `json:"long_liquidations" msgpack:"long_liquidations"`                       // USD volume
+	ShortLiquidations float64 `json:"short_liquidations" msgpack:"short_liquidations"`                     // USD volume
+	TotalVolume       float64 `json:"total_volume" msgpack:"total_volume"`                                 // Total USD volume
+	Intensity         float64 `json:"intensity" msgpack:"intensity"`                                       // 0-100 score
+	WeightedIntensity float64 `json:"weighted_intensity,omitempty" msgpack:"weighted_intensity,omitempty"` // 0-100 score, proximity weighted
+	RelativeIntensity float64 `json:"relative_intensity,omitempty" msgpack:"relative_intensity,omitempty"` // Score against a reference volume, may exceed 100
+	PriceLow          float64 `json:"price_low,omitempty" msgpack:"price_low,omitempty"`                   // Bucket lower bound for variable-width buckets
+	PriceHigh         float64 `json:"price_high,omitempty" msgpack:"price_high,omitempty"`                 // Bucket upper bound for variable-width buckets
+	OldestTimestamp   int64   `json:"oldest_timestamp,omitempty" msgpack:"oldest_timestamp,omitempty"`     // Earliest contributing event
+	NewestTimestamp   int64   `json:"newest_timestamp,omitempty" msgpack:"newest_timestamp,omitempty"`     // Latest contributing event
+	OIFraction        float64 `json:"oi_fraction,omitempty" msgpack:"oi_fraction,omitempty"`               // TotalVolume as a fraction of open interest
+	DistancePct       float64 `json:"distance_pct,omitempty" msgpack:"distance_pct,omitempty"`             // Signed % distance from the heatmap current price
+	Timestamp         int64   `json:"timestamp" msgpack:"timestamp"`
 }
 
 // LiquidationCluster represents a cluster of significant liquidation levels
 type LiquidationCluster struct {
-	Symbol          Symbol             `json:"symbol"`
-	PriceRangeStart float64            `json:"price_range_start"`
-	PriceRangeEnd   float64            `json:"price_range_end"`
-	Levels          []LiquidationLevel `json:"levels"`
-	TotalVolume     float64            `json:"total_volume"`
-	PeakIntensity   float64            `json:"peak_intensity"`
-	UpdatedAt       int64              `json:"updated_at"`
+	Symbol          Symbol             `json:"symbol" msgpack:"symbol"`
+	PriceRangeStart float64            `json:"price_range_start" msgpack:"price_range_start"`
+	PriceRangeEnd   float64            `json:"price_range_end" msgpack:"price_range_end"`
+	Levels          []LiquidationLevel `json:"levels" msgpack:"levels"`
+	TotalVolume     float64            `json:"total_volume" msgpack:"total_volume"`
+	PeakIntensity   float64            `json:"peak_intensity" msgpack:"peak_intensity"`
+	UpdatedAt       int64              `json:"updated_at" msgpack:"updated_at"`
 }
 
 // HeatmapSummary contains aggregated heatmap statistics
 type HeatmapSummary struct {
-	TotalLongLiquidations  float64        `json:"total_long_liquidations"`
-	TotalShortLiquidations float64        `json:"total_short_liquidations"`
-	MaxLiquidationPrice    float64        `json:"max_liquidation_price"`
-	MaxLiquidationVolume   float64        `json:"max_liquidation_volume"`
-	WeightedAvgLongPrice   float64        `json:"weighted_avg_long_price"`
-	WeightedAvgShortPrice  float64        `json:"weighted_avg_short_price"`
-	SignificantLevels      int            `json:"significant_levels"`
-	CriticalZones          []CriticalZone `json:"critical_zones"`
+	TotalLongLiquidations  float64        `json:"total_long_liquidations" msgpack:"total_long_liquidations"`
+	TotalShortLiquidations float64        `json:"total_short_liquidations" msgpack:"total_short_liquidations"`
+	MaxLiquidationPrice    float64        `json:"max_liquidation_price" msgpack:"max_liquidation_price"`
+	MaxLiquidationVolume   float64        `json:"max_liquidation_volume" msgpack:"max_liquidation_volume"`
+	WeightedAvgLongPrice   float64        `json:"weighted_avg_long_price" msgpack:"weighted_avg_long_price"`
+	WeightedAvgShortPrice  float64        `json:"weighted_avg_short_price" msgpack:"weighted_avg_short_price"`
+	SignificantLevels      int            `json:"significant_levels" msgpack:"significant_levels"`
+	CriticalZones          []CriticalZone `json:"critical_zones" msgpack:"critical_zones"`
 }
 
 // CriticalZone represents a high-risk liquidation zone
 type CriticalZone struct {
-	PriceStart float64 `json:"price_start"`
-	PriceEnd   float64 `json:"price_end"`
-	Type       string  `json:"type"` // "long", "short", or "mixed"
-	Intensity  float64 `json:"intensity"`
-	Volume     float64 `json:"volume"`
+	PriceStart float64 `json:"price_start" msgpack:"price_start"`
+	PriceEnd   float64 `json:"price_end" msgpack:"price_end"`
+	Type       string  `json:"type" msgpack:"type"` // "long", "short", or "mixed"
+	Intensity  float64 `json:"intensity" msgpack:"intensity"`
+	Volume     float64 `json:"volume" msgpack:"volume"`
 }
 
 // ===========================================
@@ -182,10 +220,11 @@ type CriticalZone struct {
 
 // StreamMessage represents a message for Redis Streams
 type StreamMessage struct {
-	ID        string                 `json:"id"`     // Stream message ID
-	Stream    string                 `json:"stream"` // Stream name
-	Timestamp int64                  `json:"timestamp"`
-	Data      map[string]interface{} `json:"data"`
+	ID         string                 `json:"id"`     // Stream message ID
+	Stream     string                 `json:"stream"` // Stream name
+	Timestamp  int64                  `json:"timestamp"`
+	Data       map[string]interface{} `json:"data"`
+	Compressed bool                   `json:"compressed,omitempty"` // Data holds a single gzip payload field
 }
 
 // ToStreamMessage converts any model to a StreamMessage
@@ -204,32 +243,81 @@ func ToStreamMessage(streamName string, v interface{}) (*StreamMessage, error) {
 
 // structToMap converts a struct to a map for Redis
 func structToMap(v interface{}) (map[string]interface{}, error) {
+	return structToMapNested(v, func(val interface{}) (interface{}, error) {
+		jsonBytes, err := json.Marshal(val)
+		return string(jsonBytes), err
+	})
+}
+
+// structToMapNested converts a struct to a flat map, keeping scalars typed
+// and encoding nested objects and arrays with encodeNested
+func structToMapNested(v interface{}, encodeNested func(interface{}) (interface{}, error)) (map[string]interface{}, error) {
 	data, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
 
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
 	var m map[string]interface{}
-	if err := json.Unmarshal(data, &m); err != nil {
+	if err := decoder.Decode(&m); err != nil {
 		return nil, err
 	}
 
-	// Flatten the map for Redis (convert nested objects to JSON strings)
+	var fields map[string]reflect.Type
+	if t := reflect.TypeOf(v); t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			fields = jsonFieldTypes(t)
+		}
+	}
+
+	// Flatten the map for Redis (keep scalars typed, encode nested objects)
 	result := make(map[string]interface{})
 	for k, v := range m {
 		switch val := v.(type) {
-		case string, int, int64, float64, bool:
-			result[k] = fmt.Sprintf("%v", val)
+		case string, bool:
+			result[k] = val
+		case json.Number:
+			result[k] = typedNumber(val, fields[k])
 		default:
-			// For complex types, store as JSON
-			jsonBytes, _ := json.Marshal(val)
-			result[k] = string(jsonBytes)
+			// For complex types, store encoded
+			encoded, err := encodeNested(val)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", k, err)
+			}
+			result[k] = encoded
 		}
 	}
 
 	return result, nil
 }
 
+// typedNumber converts a JSON number to int, int64, or float64 according to
+// the kind of the struct field it was marshalled from
+func typedNumber(n json.Number, t reflect.Type) interface{} {
+	if t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Int:
+			if i, err := n.Int64(); err == nil {
+				return int(i)
+			}
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if i, err := n.Int64(); err == nil {
+				return i
+			}
+		}
+	}
+	f, _ := n.Float64()
+	return f
+}
+
 // ===========================================
 // STREAM NAME GENERATORS
 // ===========================================
@@ -263,60 +351,159 @@ func GetHeatmapCacheKey(symbol Symbol, interval Interval) string {
 	return fmt.Sprintf("heatmap:cache:%s:%s", symbol, interval)
 }
 
+// GetConsumerGroupName generates the Redis Streams consumer group name for a
+// service reading a data type, e.g. "cg:aggregator:liquidations". Returns an
+// empty string if either part is empty after sanitization
+func GetConsumerGroupName(service string, dataType string) string {
+	return consumerKey("cg", service, dataType)
+}
+
+// GetConsumerName generates the consumer name for one service instance, e.g.
+// "consumer:aggregator:pod-7". Returns an empty string if either part is empty
+// after sanitization
+func GetConsumerName(service string, instanceID string) string {
+	return consumerKey("consumer", service, instanceID)
+}
+
+// consumerKey joins sanitized parts under prefix, or returns "" if any is empty
+func consumerKey(prefix string, parts ...string) string {
+	segments := []string{prefix}
+	for _, part := range parts {
+		part = sanitizeNamePart(part)
+		if part == "" {
+			return ""
+		}
+		segments = append(segments, part)
+	}
+	return strings.Join(segments, ":")
+}
+
+// sanitizeNamePart trims a name segment and replaces colons and whitespace,
+// which would break the colon-delimited naming scheme, with dashes
+func sanitizeNamePart(part string) string {
+	part = strings.TrimSpace(part)
+	return strings.Map(func(r rune) rune {
+		if r == ':' || unicode.IsSpace(r) {
+			return '-'
+		}
+		return r
+	}, part)
+}
+
+// ParseStreamName splits a name built by GetStreamName back into its parts.
+// Two-part names such as "heatmap:BTCUSDT" return an empty exchange
+func ParseStreamName(name string) (dataType string, exchange Exchange, symbol Symbol, err error) {
+	parts := strings.Split(name, ":")
+	for _, part := range parts {
+		if part == "" {
+			return "", "", "", fmt.Errorf("malformed stream name %q", name)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return parts[0], "", Symbol(parts[1]), nil
+	case 3:
+		return parts[0], Exchange(parts[1]), Symbol(parts[2]), nil
+	default:
+		return "", "", "", fmt.Errorf("malformed stream name %q", name)
+	}
+}
+
 // ===========================================
 // VALIDATION METHODS
 // ===========================================
 
 // Validate checks if MarketSnapshot is valid
 func (m *MarketSnapshot) Validate() error {
-	if m.Exchange == "" {
-		return fmt.Errorf("exchange is required")
-	}
-	if m.Symbol == "" {
-		return fmt.Errorf("symbol is required")
-	}
-	if m.Timestamp <= 0 {
-		return fmt.Errorf("invalid timestamp")
-	}
-	if m.MarkPrice <= 0 {
-		return fmt.Errorf("invalid mark price")
-	}
-	return nil
+	return firstError(m.ValidateAll())
+}
+
+// ValidateAll returns every failing MarketSnapshot check, in field order
+func (m *MarketSnapshot) ValidateAll() []error {
+	return collectErrors(
+		validationCheck{m.Exchange == "", "exchange is required"},
+		validationCheck{m.Symbol == "", "symbol is required"},
+		validationCheck{m.Timestamp <= 0, "invalid timestamp"},
+		validationCheck{m.MarkPrice <= 0, "invalid mark price"},
+		validationCheck{m.IndexPrice < 0, "invalid index price"},
+		validationCheck{math.IsNaN(m.FundingRate) || math.IsInf(m.FundingRate, 0) ||
+			math.Abs(m.FundingRate) > MaxFundingRate, "funding rate out of range"},
+		validationCheck{m.OpenInterestUSD < 0, "negative open interest"},
+	)
 }
 
 // Validate checks if LiquidationEvent is valid
 func (l *LiquidationEvent) Validate() error {
-	if l.Exchange == "" {
-		return fmt.Errorf("exchange is required")
-	}
-	if l.Symbol == "" {
-		return fmt.Errorf("symbol is required")
-	}
-	if l.Timestamp <= 0 {
-		return fmt.Errorf("invalid timestamp")
-	}
-	if l.Price <= 0 {
-		return fmt.Errorf("invalid price")
-	}
-	if l.Quantity <= 0 {
-		return fmt.Errorf("invalid quantity")
-	}
-	return nil
+	return firstError(l.ValidateAll())
+}
+
+// ValidateAll returns every failing LiquidationEvent check, in field order
+func (l *LiquidationEvent) ValidateAll() []error {
+	return collectErrors(
+		validationCheck{l.Exchange == "", "exchange is required"},
+		validationCheck{l.Symbol == "", "symbol is required"},
+		validationCheck{l.Timestamp <= 0, "invalid timestamp"},
+		validationCheck{l.Price <= 0, "invalid price"},
+		validationCheck{l.Quantity <= 0, "invalid quantity"},
+	)
 }
 
 // Validate checks if HeatmapData is valid
 func (h *HeatmapData) Validate() error {
-	if h.Symbol == "" {
+	return firstError(h.ValidateAll())
+}
+
+// ValidateAll returns every failing HeatmapData check, in field order,
+// followed by per-level checks: every level price must be positive and, when
+// Interval is set, every non-zero level Timestamp must fall within the
+// interval window ending at the heatmap Timestamp
+func (h *HeatmapData) ValidateAll() []error {
+	errs := collectErrors(
+		validationCheck{h.Symbol == "", "symbol is required"},
+		validationCheck{h.Timestamp <= 0, "invalid timestamp"},
+		validationCheck{h.CurrentPrice <= 0, "invalid current price"},
+		validationCheck{len(h.Levels) == 0, "no liquidation levels"},
+	)
+
+	windowStart := h.Timestamp - GetIntervalDuration(h.Interval).Milliseconds()
+	for i, level := range h.Levels {
+		if level.Price <= 0 {
+			errs = append(errs, fmt.Errorf("invalid level %d: price %v", i, level.Price))
+		}
+		if h.Interval != "" && level.Timestamp != 0 && (level.Timestamp < windowStart || level.Timestamp > h.Timestamp) {
+			errs = append(errs, fmt.Errorf("invalid level %d: timestamp %d outside interval window", i, level.Timestamp))
+		}
+	}
+	return errs
+}
+
+// Validate checks if OrderBookSnapshot is valid
+func (o *OrderBookSnapshot) Validate() error {
+	if o.Exchange == "" {
+		return fmt.Errorf("exchange is required")
+	}
+	if o.Symbol == "" {
 		return fmt.Errorf("symbol is required")
 	}
-	if h.Timestamp <= 0 {
+	if o.Timestamp <= 0 {
 		return fmt.Errorf("invalid timestamp")
 	}
-	if h.CurrentPrice <= 0 {
-		return fmt.Errorf("invalid current price")
+	if len(o.Bids) == 0 && len(o.Asks) == 0 {
+		return fmt.Errorf("no price levels")
 	}
-	if len(h.Levels) == 0 {
-		return fmt.Errorf("no liquidation levels")
+	for i, level := range o.Bids {
+		if level.Price <= 0 || level.Quantity < 0 {
+			return fmt.Errorf("invalid bid level %d: price %v quantity %v", i, level.Price, level.Quantity)
+		}
+	}
+	for i, level := range o.Asks {
+		if level.Price <= 0 || level.Quantity < 0 {
+			return fmt.Errorf("invalid ask level %d: price %v quantity %v", i, level.Price, level.Quantity)
+		}
+	}
+	if bid, ask, ok := o.bestPrices(); ok && bid > ask {
+		return fmt.Errorf("crossed book: best bid %v exceeds best ask %v", bid, ask)
 	}
 	return nil
 }
@@ -327,23 +514,42 @@ func (h *HeatmapData) Validate() error {
 
 // GetLiquidationType returns the liquidation type based on side
 func (l *LiquidationEvent) GetLiquidationType() string {
-	switch l.Side {
-	case SideSell:
-		return "LONG" // Long positions get liquidated with sell orders
-	case SideBuy:
-		return "SHORT" // Short positions get liquidated with buy orders
-	default:
-		if l.Side == SideLong {
-			return "LONG"
-		}
-		return "SHORT"
+	if l.Side.Normalize() == SideLong {
+		return "LONG"
+	}
+	return "SHORT"
+}
+
+// defaultMaintenanceMargin is the maintenance margin rate used for exchanges
+// missing from MaintenanceMargins
+const defaultMaintenanceMargin = 0.004 // 0.4% for Binance
+
+// MaintenanceMargins holds the default maintenance margin rate per exchange
+// used by leverage estimates
+var MaintenanceMargins = map[Exchange]float64{
+	ExchangeBinance: 0.004,
+	ExchangeOKX:     0.005,
+	ExchangeBybit:   0.005,
+	ExchangeDeribit: 0.01,
+}
+
+// maintenanceMarginFor returns the maintenance margin rate for an exchange
+func maintenanceMarginFor(exchange Exchange) float64 {
+	if margin, ok := MaintenanceMargins[exchange]; ok {
+		return margin
 	}
+	return defaultMaintenanceMargin
 }
 
 // GetEstimatedLeverage estimates the leverage used based on liquidation price
+// and the maintenance margin of the event's exchange
 func (l *LiquidationEvent) GetEstimatedLeverage(markPrice float64) float64 {
-	maintenanceMargin := 0.004 // 0.4% for Binance
+	return l.GetEstimatedLeverageWithMargin(markPrice, maintenanceMarginFor(l.Exchange))
+}
 
+// GetEstimatedLeverageWithMargin estimates the leverage used based on
+// liquidation price and an explicit maintenance margin rate
+func (l *LiquidationEvent) GetEstimatedLeverageWithMargin(markPrice, maintenanceMargin float64) float64 {
 	if l.GetLiquidationType() == "LONG" {
 		if markPrice > 0 && l.Price < markPrice {
 			return 1 / (1 - l.Price/markPrice + maintenanceMargin)
@@ -359,9 +565,20 @@ func (l *LiquidationEvent) GetEstimatedLeverage(markPrice float64) float64 {
 
 // CalculateIntensity calculates the intensity score for a liquidation level
 func (ll *LiquidationLevel) CalculateIntensity(maxVolume float64) {
-	if maxVolume > 0 {
-		ll.Intensity = (ll.TotalVolume / maxVolume) * 100
+	if maxVolume <= 0 {
+		ll.Intensity = 0
+		return
+	}
+	ll.Intensity = (ll.TotalVolume / maxVolume) * 100
+}
+
+// AgeSpan returns the time between the oldest and newest events that
+// contributed to the level, or 0 when the bracket isn't populated
+func (ll *LiquidationLevel) AgeSpan() time.Duration {
+	if ll.OldestTimestamp == 0 || ll.NewestTimestamp < ll.OldestTimestamp {
+		return 0
 	}
+	return time.Duration(ll.NewestTimestamp-ll.OldestTimestamp) * time.Millisecond
 }
 
 // IsSignificant determines if a liquidation level is significant
@@ -386,15 +603,79 @@ func GetIntervalDuration(interval Interval) time.Duration {
 		return 4 * time.Hour
 	case Interval1d:
 		return 24 * time.Hour
+	case Interval1w:
+		return 7 * 24 * time.Hour
 	default:
 		return time.Minute
 	}
 }
 
-// RoundToInterval rounds a timestamp to the nearest interval
+// Seconds returns the interval length in seconds; unknown intervals count as 1m
+func (i Interval) Seconds() int64 {
+	return int64(GetIntervalDuration(i) / time.Second)
+}
+
+// CompareIntervals returns -1, 0 or 1 as a is shorter than, as long as, or
+// longer than b. Unknown intervals compare as 1m
+func CompareIntervals(a, b Interval) int {
+	da, db := GetIntervalDuration(a), GetIntervalDuration(b)
+	switch {
+	case da < db:
+		return -1
+	case da > db:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CoarsestInterval returns the longest of intervals, the first on ties, or
+// an empty Interval when none are given
+func CoarsestInterval(intervals ...Interval) Interval {
+	var coarsest Interval
+	for i, interval := range intervals {
+		if i == 0 || CompareIntervals(interval, coarsest) > 0 {
+			coarsest = interval
+		}
+	}
+	return coarsest
+}
+
+// RoundToInterval rounds a timestamp down to the start of its interval.
+// Day and week intervals are aligned to UTC calendar boundaries, with weeks
+// starting on WeekStart
 func RoundToInterval(timestamp int64, interval Interval) int64 {
+	switch interval {
+	case Interval1d, Interval1w:
+		return roundToCalendarInterval(timestamp, interval)
+	}
+
 	duration := GetIntervalDuration(interval)
 	t := time.UnixMilli(timestamp)
 	rounded := t.Truncate(duration)
 	return rounded.UnixMilli()
 }
+
+// roundToCalendarInterval returns UTC midnight of the timestamp's day, or of
+// the most recent WeekStart for weekly intervals
+func roundToCalendarInterval(timestamp int64, interval Interval) int64 {
+	t := time.UnixMilli(timestamp).UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == Interval1w {
+		offset := (int(day.Weekday()) - int(WeekStart) + 7) % 7
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day.UnixMilli()
+}
+
+// NextIntervalBoundary returns the start of the interval following the one
+// containing timestamp; a timestamp exactly on a boundary gets the next one
+func NextIntervalBoundary(timestamp int64, interval Interval) int64 {
+	return RoundToInterval(timestamp, interval) + GetIntervalDuration(interval).Milliseconds()
+}
+
+// TimeUntilNextBoundary returns how long from now until NextIntervalBoundary,
+// so a scheduler can sleep exactly until the next flush
+func TimeUntilNextBoundary(now int64, interval Interval) time.Duration {
+	return time.Duration(NextIntervalBoundary(now, interval)-now) * time.Millisecond
+}
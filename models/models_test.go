@@ -1,6 +1,9 @@
 package models
 
 import (
+	"math"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 )
@@ -148,6 +151,73 @@ func TestMarketSnapshotValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "funding rate at bound",
+			market: MarketSnapshot{
+				Exchange:    ExchangeBinance,
+				Symbol:      SymbolBTCUSDT,
+				Timestamp:   time.Now().UnixMilli(),
+				MarkPrice:   45000.0,
+				IndexPrice:  44990.0,
+				FundingRate: -0.05,
+			},
+			wantErr: false,
+		},
+		{
+			name: "funding rate out of range",
+			market: MarketSnapshot{
+				Exchange:    ExchangeBinance,
+				Symbol:      SymbolBTCUSDT,
+				Timestamp:   time.Now().UnixMilli(),
+				MarkPrice:   45000.0,
+				FundingRate: 5.0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "funding rate NaN",
+			market: MarketSnapshot{
+				Exchange:    ExchangeBinance,
+				Symbol:      SymbolBTCUSDT,
+				Timestamp:   time.Now().UnixMilli(),
+				MarkPrice:   45000.0,
+				FundingRate: math.NaN(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "funding rate infinite",
+			market: MarketSnapshot{
+				Exchange:    ExchangeBinance,
+				Symbol:      SymbolBTCUSDT,
+				Timestamp:   time.Now().UnixMilli(),
+				MarkPrice:   45000.0,
+				FundingRate: math.Inf(-1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative index price",
+			market: MarketSnapshot{
+				Exchange:   ExchangeBinance,
+				Symbol:     SymbolBTCUSDT,
+				Timestamp:  time.Now().UnixMilli(),
+				MarkPrice:  45000.0,
+				IndexPrice: -1.0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative open interest",
+			market: MarketSnapshot{
+				Exchange:        ExchangeBinance,
+				Symbol:          SymbolBTCUSDT,
+				Timestamp:       time.Now().UnixMilli(),
+				MarkPrice:       45000.0,
+				OpenInterestUSD: -1000.0,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -214,6 +284,45 @@ func TestHeatmapDataValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "level inside interval window",
+			heatmap: HeatmapData{
+				Symbol:       SymbolBTCUSDT,
+				Timestamp:    1700000060000,
+				Interval:     Interval1m,
+				CurrentPrice: 45000.0,
+				Levels: []LiquidationLevel{
+					{Price: 44000.0, Timestamp: 1700000030000},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "level outside interval window",
+			heatmap: HeatmapData{
+				Symbol:       SymbolBTCUSDT,
+				Timestamp:    1700000060000,
+				Interval:     Interval1m,
+				CurrentPrice: 45000.0,
+				Levels: []LiquidationLevel{
+					{Price: 44000.0, Timestamp: 1700000030000},
+					{Price: 44500.0, Timestamp: 1699999000000},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative level price",
+			heatmap: HeatmapData{
+				Symbol:       SymbolBTCUSDT,
+				Timestamp:    time.Now().UnixMilli(),
+				CurrentPrice: 45000.0,
+				Levels: []LiquidationLevel{
+					{Price: -44000.0},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,6 +335,112 @@ func TestHeatmapDataValidation(t *testing.T) {
 	}
 }
 
+func TestOrderBookSnapshotValidation(t *testing.T) {
+	valid := func() OrderBookSnapshot {
+		return OrderBookSnapshot{
+			Exchange:  ExchangeBinance,
+			Symbol:    SymbolBTCUSDT,
+			Timestamp: time.Now().UnixMilli(),
+			Bids:      []PriceLevel{{Price: 44990.0, Quantity: 1.0}, {Price: 44980.0, Quantity: 2.0}},
+			Asks:      []PriceLevel{{Price: 45010.0, Quantity: 1.5}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		book    func() OrderBookSnapshot
+		wantErr bool
+	}{
+		{
+			name:    "valid order book",
+			book:    valid,
+			wantErr: false,
+		},
+		{
+			name: "one-sided book",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Asks = nil
+				return o
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing exchange",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Exchange = ""
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing symbol",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Symbol = ""
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timestamp",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Timestamp = 0
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty book",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Bids, o.Asks = nil, nil
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-positive price",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Bids[1].Price = 0
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative quantity",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Asks[0].Quantity = -1
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "crossed book",
+			book: func() OrderBookSnapshot {
+				o := valid()
+				o.Bids[0].Price = 45020.0
+				return o
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := tt.book()
+			err := book.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OrderBookSnapshot.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestGetLiquidationType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -344,6 +559,33 @@ func TestGetEstimatedLeverage(t *testing.T) {
 	}
 }
 
+func TestGetEstimatedLeveragePerExchange(t *testing.T) {
+	binance := LiquidationEvent{Exchange: ExchangeBinance, Side: SideSell, Price: 36000.0}
+	deribit := LiquidationEvent{Exchange: ExchangeDeribit, Side: SideSell, Price: 36000.0}
+	unknown := LiquidationEvent{Exchange: Exchange("unknown"), Side: SideSell, Price: 36000.0}
+
+	binanceLeverage := binance.GetEstimatedLeverage(40000.0)
+	deribitLeverage := deribit.GetEstimatedLeverage(40000.0)
+
+	if expected := 1 / (1 - 0.9 + 0.004); math.Abs(binanceLeverage-expected) > 1e-9 {
+		t.Errorf("Binance GetEstimatedLeverage() = %v, expected %v", binanceLeverage, expected)
+	}
+	if expected := 1 / (1 - 0.9 + 0.01); math.Abs(deribitLeverage-expected) > 1e-9 {
+		t.Errorf("Deribit GetEstimatedLeverage() = %v, expected %v", deribitLeverage, expected)
+	}
+	if deribitLeverage >= binanceLeverage {
+		t.Errorf("higher-margin exchange leverage %v should be below Binance %v", deribitLeverage, binanceLeverage)
+	}
+	if result := unknown.GetEstimatedLeverage(40000.0); math.Abs(result-binanceLeverage) > 1e-9 {
+		t.Errorf("unknown exchange GetEstimatedLeverage() = %v, expected fallback %v", result, binanceLeverage)
+	}
+
+	explicit := binance.GetEstimatedLeverageWithMargin(40000.0, 0.02)
+	if expected := 1 / (1 - 0.9 + 0.02); math.Abs(explicit-expected) > 1e-9 {
+		t.Errorf("GetEstimatedLeverageWithMargin() = %v, expected %v", explicit, expected)
+	}
+}
+
 func TestCalculateIntensity(t *testing.T) {
 	level := LiquidationLevel{
 		Price:       45000.0,
@@ -442,6 +684,121 @@ func TestStreamNameGeneration(t *testing.T) {
 	}
 }
 
+func TestParseStreamName(t *testing.T) {
+	tests := []struct {
+		name             string
+		stream           string
+		expectedDataType string
+		expectedExchange Exchange
+		expectedSymbol   Symbol
+		wantErr          bool
+	}{
+		{
+			name:             "liquidation stream",
+			stream:           GetLiquidationStreamName(ExchangeBinance, SymbolBTCUSDT),
+			expectedDataType: "liquidations",
+			expectedExchange: ExchangeBinance,
+			expectedSymbol:   SymbolBTCUSDT,
+		},
+		{
+			name:             "market stream",
+			stream:           GetMarketStreamName(ExchangeOKX, SymbolETHUSDT),
+			expectedDataType: "market",
+			expectedExchange: ExchangeOKX,
+			expectedSymbol:   SymbolETHUSDT,
+		},
+		{
+			name:             "orderbook stream",
+			stream:           GetOrderBookStreamName(ExchangeBybit, SymbolBNBUSDT),
+			expectedDataType: "orderbook",
+			expectedExchange: ExchangeBybit,
+			expectedSymbol:   SymbolBNBUSDT,
+		},
+		{
+			name:             "heatmap stream",
+			stream:           GetHeatmapStreamName(SymbolBTCUSDT),
+			expectedDataType: "heatmap",
+			expectedSymbol:   SymbolBTCUSDT,
+		},
+		{
+			name:    "single part",
+			stream:  "liquidations",
+			wantErr: true,
+		},
+		{
+			name:    "empty segment",
+			stream:  "liquidations::BTCUSDT",
+			wantErr: true,
+		},
+		{
+			name:    "too many parts",
+			stream:  GetHeatmapCacheKey(SymbolBTCUSDT, Interval1m),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataType, exchange, symbol, err := ParseStreamName(tt.stream)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStreamName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if dataType != tt.expectedDataType || exchange != tt.expectedExchange || symbol != tt.expectedSymbol {
+				t.Errorf("ParseStreamName() = %v, %v, %v, expected %v, %v, %v",
+					dataType, exchange, symbol, tt.expectedDataType, tt.expectedExchange, tt.expectedSymbol)
+			}
+		})
+	}
+}
+
+func TestConsumerNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		function func() string
+		expected string
+	}{
+		{
+			name:     "consumer group",
+			function: func() string { return GetConsumerGroupName("aggregator", "liquidations") },
+			expected: "cg:aggregator:liquidations",
+		},
+		{
+			name:     "consumer",
+			function: func() string { return GetConsumerName("aggregator", "pod-7") },
+			expected: "consumer:aggregator:pod-7",
+		},
+		{
+			name:     "colons sanitized",
+			function: func() string { return GetConsumerGroupName("risk:alerter", "heatmap") },
+			expected: "cg:risk-alerter:heatmap",
+		},
+		{
+			name:     "whitespace sanitized",
+			function: func() string { return GetConsumerName(" archiver ", "node 3") },
+			expected: "consumer:archiver:node-3",
+		},
+		{
+			name:     "empty service",
+			function: func() string { return GetConsumerGroupName("", "liquidations") },
+			expected: "",
+		},
+		{
+			name:     "blank instance",
+			function: func() string { return GetConsumerName("aggregator", "  ") },
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.function()
+			if result != tt.expected {
+				t.Errorf("Consumer name = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetIntervalDuration(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -478,6 +835,11 @@ func TestGetIntervalDuration(t *testing.T) {
 			interval: Interval1d,
 			expected: 24 * time.Hour,
 		},
+		{
+			name:     "1 week interval",
+			interval: Interval1w,
+			expected: 7 * 24 * time.Hour,
+		},
 		{
 			name:     "unknown interval defaults to minute",
 			interval: Interval("unknown"),
@@ -495,6 +857,49 @@ func TestGetIntervalDuration(t *testing.T) {
 	}
 }
 
+func TestIntervalOrdering(t *testing.T) {
+	shuffled := []Interval{Interval4h, Interval1s, Interval1w, Interval15m, Interval1m, Interval1d, Interval5m, Interval1h}
+	sort.Slice(shuffled, func(i, j int) bool {
+		return CompareIntervals(shuffled[i], shuffled[j]) < 0
+	})
+	expected := []Interval{Interval1s, Interval1m, Interval5m, Interval15m, Interval1h, Interval4h, Interval1d, Interval1w}
+	if !reflect.DeepEqual(shuffled, expected) {
+		t.Errorf("sorted intervals = %v, expected %v", shuffled, expected)
+	}
+
+	tests := []struct {
+		name      string
+		intervals []Interval
+		expected  Interval
+	}{
+		{name: "mixed", intervals: []Interval{Interval5m, Interval1d, Interval1h}, expected: Interval1d},
+		{name: "unknown counts as 1m", intervals: []Interval{Interval1s, "3m"}, expected: "3m"},
+		{name: "tie keeps first", intervals: []Interval{"3m", Interval1m}, expected: "3m"},
+		{name: "empty", intervals: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CoarsestInterval(tt.intervals...); result != tt.expected {
+				t.Errorf("CoarsestInterval() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	if result := Interval1h.Seconds(); result != 3600 {
+		t.Errorf("Seconds() = %v, expected 3600", result)
+	}
+	if result := Interval("3m").Seconds(); result != 60 {
+		t.Errorf("Seconds() for unknown interval = %v, expected 60", result)
+	}
+	if result := CompareIntervals(Interval1w, Interval1d); result != 1 {
+		t.Errorf("CompareIntervals() = %v, expected 1", result)
+	}
+	if result := CompareIntervals("bogus", Interval1m); result != 0 {
+		t.Errorf("CompareIntervals() for unknown interval = %v, expected 0", result)
+	}
+}
+
 func TestRoundToInterval(t *testing.T) {
 	baseTime := time.Date(2024, 1, 1, 12, 34, 56, 789000000, time.UTC)
 	tests := []struct {
@@ -527,6 +932,24 @@ func TestRoundToInterval(t *testing.T) {
 			interval:  Interval1d,
 			expected:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:      "round mid-week to monday",
+			timestamp: time.Date(2024, 1, 11, 15, 30, 0, 0, time.UTC).UnixMilli(), // Thursday
+			interval:  Interval1w,
+			expected:  time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "week start rounds to itself",
+			timestamp: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC).UnixMilli(),
+			interval:  Interval1w,
+			expected:  time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "sunday rounds back to previous monday",
+			timestamp: time.Date(2024, 1, 14, 23, 59, 0, 0, time.UTC).UnixMilli(),
+			interval:  Interval1w,
+			expected:  time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	for _, tt := range tests {
@@ -541,6 +964,103 @@ func TestRoundToInterval(t *testing.T) {
 	}
 }
 
+func TestRoundToIntervalSundayWeekStart(t *testing.T) {
+	defer func(start time.Weekday) { WeekStart = start }(WeekStart)
+	WeekStart = time.Sunday
+
+	thursday := time.Date(2024, 1, 11, 15, 30, 0, 0, time.UTC).UnixMilli()
+	result := RoundToInterval(thursday, Interval1w)
+	expected := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC).UnixMilli()
+	if result != expected {
+		t.Errorf("RoundToInterval() = %v, expected %v",
+			time.UnixMilli(result).UTC(), time.UnixMilli(expected).UTC())
+	}
+
+	sunday := time.Date(2024, 1, 14, 1, 0, 0, 0, time.UTC).UnixMilli()
+	result = RoundToInterval(sunday, Interval1w)
+	expected = time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC).UnixMilli()
+	if result != expected {
+		t.Errorf("RoundToInterval() = %v, expected %v",
+			time.UnixMilli(result).UTC(), time.UnixMilli(expected).UTC())
+	}
+}
+
+func TestNextIntervalBoundary(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 12, 34, 56, 789000000, time.UTC)
+	tests := []struct {
+		name      string
+		timestamp int64
+		interval  Interval
+		expected  time.Time
+	}{
+		{
+			name:      "next minute",
+			timestamp: baseTime.UnixMilli(),
+			interval:  Interval1m,
+			expected:  time.Date(2024, 1, 1, 12, 35, 0, 0, time.UTC),
+		},
+		{
+			name:      "minute boundary",
+			timestamp: time.Date(2024, 1, 1, 12, 34, 0, 0, time.UTC).UnixMilli(),
+			interval:  Interval1m,
+			expected:  time.Date(2024, 1, 1, 12, 35, 0, 0, time.UTC),
+		},
+		{
+			name:      "next hour",
+			timestamp: baseTime.UnixMilli(),
+			interval:  Interval1h,
+			expected:  time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "hour boundary",
+			timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).UnixMilli(),
+			interval:  Interval1h,
+			expected:  time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NextIntervalBoundary(tt.timestamp, tt.interval)
+			expected := tt.expected.UnixMilli()
+			if result != expected {
+				t.Errorf("NextIntervalBoundary() = %v, expected %v",
+					time.UnixMilli(result), time.UnixMilli(expected))
+			}
+		})
+	}
+}
+
+func TestTimeUntilNextBoundary(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval Interval
+		expected time.Duration
+	}{
+		{"on boundary waits a full interval", start, Interval1m, time.Minute},
+		{"mid interval", start.Add(20 * time.Second), Interval1m, 40 * time.Second},
+		{"just before boundary", start.Add(time.Minute - time.Millisecond), Interval1m, time.Millisecond},
+		{"within 5m interval", start.Add(7*time.Minute + 30*time.Second), Interval5m, 2*time.Minute + 30*time.Second},
+		{"within hour", start.Add(45 * time.Minute), Interval1h, 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := tt.now.UnixMilli()
+			result := TimeUntilNextBoundary(now, tt.interval)
+			if result != tt.expected {
+				t.Errorf("TimeUntilNextBoundary() = %v, expected %v", result, tt.expected)
+			}
+			if next := now + result.Milliseconds(); next != NextIntervalBoundary(now, tt.interval) {
+				t.Errorf("now + TimeUntilNextBoundary() = %v, expected NextIntervalBoundary() %v",
+					next, NextIntervalBoundary(now, tt.interval))
+			}
+		})
+	}
+}
+
 func TestToStreamMessage(t *testing.T) {
 	event := LiquidationEvent{
 		Exchange:  ExchangeBinance,
@@ -572,6 +1092,53 @@ func TestToStreamMessage(t *testing.T) {
 	}
 }
 
+func TestStructToMapPreservesScalarTypes(t *testing.T) {
+	event := LiquidationEvent{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: 1700000000123,
+		Side:      SideLong,
+		Price:     45000.0,
+		Quantity:  1.5,
+		Value:     67500.0,
+		OrderType: OrderTypeLiquidation,
+	}
+
+	msg, err := ToStreamMessage("test-stream", event)
+	if err != nil {
+		t.Fatalf("ToStreamMessage() error = %v", err)
+	}
+
+	if price, ok := msg.Data["price"].(float64); !ok || price != 45000.0 {
+		t.Errorf("price = %#v, expected float64 45000", msg.Data["price"])
+	}
+	if quantity, ok := msg.Data["quantity"].(float64); !ok || quantity != 1.5 {
+		t.Errorf("quantity = %#v, expected float64 1.5", msg.Data["quantity"])
+	}
+	if timestamp, ok := msg.Data["timestamp"].(int64); !ok || timestamp != event.Timestamp {
+		t.Errorf("timestamp = %#v, expected int64 %v", msg.Data["timestamp"], event.Timestamp)
+	}
+	if exchange, ok := msg.Data["exchange"].(string); !ok || exchange != "binance" {
+		t.Errorf("exchange = %#v, expected string binance", msg.Data["exchange"])
+	}
+
+	heatmap := HeatmapData{
+		Symbol:  SymbolBTCUSDT,
+		Levels:  []LiquidationLevel{{Price: 44000.0}},
+		Summary: HeatmapSummary{SignificantLevels: 3},
+	}
+	msg, err = ToStreamMessage("heatmap-stream", heatmap)
+	if err != nil {
+		t.Fatalf("ToStreamMessage() error = %v", err)
+	}
+	if _, ok := msg.Data["current_price"].(float64); !ok {
+		t.Errorf("current_price = %#v, expected float64", msg.Data["current_price"])
+	}
+	if _, ok := msg.Data["levels"].(string); !ok {
+		t.Error("Levels should still be serialized as JSON string")
+	}
+}
+
 func TestStructToMapConversion(t *testing.T) {
 	// Test with nested structure
 	heatmap := HeatmapData{
@@ -0,0 +1,248 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// ===========================================
+// ORDER BOOK HELPERS
+// ===========================================
+
+// Clone returns a deep copy of the order book whose Bids and Asks can be
+// mutated without affecting o
+func (o *OrderBookSnapshot) Clone() OrderBookSnapshot {
+	clone := *o
+	clone.Bids = slices.Clone(o.Bids)
+	clone.Asks = slices.Clone(o.Asks)
+	return clone
+}
+
+// BestBid returns the highest-priced bid without assuming Bids is sorted.
+// ok is false when there are no bids
+func (o *OrderBookSnapshot) BestBid() (PriceLevel, bool) {
+	if len(o.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+	best := o.Bids[0]
+	for _, level := range o.Bids[1:] {
+		if level.Price > best.Price {
+			best = level
+		}
+	}
+	return best, true
+}
+
+// BestAsk returns the lowest-priced ask without assuming Asks is sorted.
+// ok is false when there are no asks
+func (o *OrderBookSnapshot) BestAsk() (PriceLevel, bool) {
+	if len(o.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+	best := o.Asks[0]
+	for _, level := range o.Asks[1:] {
+		if level.Price < best.Price {
+			best = level
+		}
+	}
+	return best, true
+}
+
+// Sort orders Bids by descending price and Asks by ascending price in place
+// so Bids[0] and Asks[0] are the top of book. Levels at equal prices keep
+// their relative order
+func (o *OrderBookSnapshot) Sort() {
+	sort.SliceStable(o.Bids, func(i, j int) bool { return o.Bids[i].Price > o.Bids[j].Price })
+	sort.SliceStable(o.Asks, func(i, j int) bool { return o.Asks[i].Price < o.Asks[j].Price })
+}
+
+// IsSorted reports whether Bids are in descending and Asks in ascending
+// price order
+func (o *OrderBookSnapshot) IsSorted() bool {
+	return sort.SliceIsSorted(o.Bids, func(i, j int) bool { return o.Bids[i].Price > o.Bids[j].Price }) &&
+		sort.SliceIsSorted(o.Asks, func(i, j int) bool { return o.Asks[i].Price < o.Asks[j].Price })
+}
+
+// ApplyDelta merges an incremental update into the book: changed levels are
+// upserted by price, zero-quantity levels are removed, and both sides are
+// left sorted. Deltas for another market, with negative quantities, or
+// with a LastUpdateID not newer than the book's are rejected without
+// modifying the book
+func (o *OrderBookSnapshot) ApplyDelta(d OrderBookDelta) error {
+	if d.Exchange != o.Exchange || d.Symbol != o.Symbol {
+		return fmt.Errorf("delta for %s %s applied to %s %s book", d.Exchange, d.Symbol, o.Exchange, o.Symbol)
+	}
+	if d.LastUpdateID <= o.LastUpdateID {
+		return fmt.Errorf("stale delta: update id %d not after %d", d.LastUpdateID, o.LastUpdateID)
+	}
+	for _, level := range append(append([]PriceLevel(nil), d.Bids...), d.Asks...) {
+		if level.Quantity < 0 {
+			return fmt.Errorf("invalid delta level: price %v quantity %v", level.Price, level.Quantity)
+		}
+	}
+
+	o.Bids = mergeBookSide(o.Bids, d.Bids)
+	o.Asks = mergeBookSide(o.Asks, d.Asks)
+	o.Sort()
+	o.LastUpdateID = d.LastUpdateID
+	if d.Timestamp > o.Timestamp {
+		o.Timestamp = d.Timestamp
+	}
+	return nil
+}
+
+// mergeBookSide upserts updates into levels by price, dropping levels whose
+// updated quantity is zero
+func mergeBookSide(levels, updates []PriceLevel) []PriceLevel {
+	if len(updates) == 0 {
+		return levels
+	}
+
+	byPrice := make(map[float64]int, len(levels))
+	merged := append([]PriceLevel(nil), levels...)
+	for i, level := range merged {
+		byPrice[level.Price] = i
+	}
+	for _, update := range updates {
+		if i, ok := byPrice[update.Price]; ok {
+			merged[i] = update
+			continue
+		}
+		byPrice[update.Price] = len(merged)
+		merged = append(merged, update)
+	}
+
+	result := merged[:0]
+	for _, level := range merged {
+		if level.Quantity > 0 {
+			result = append(result, level)
+		}
+	}
+	return result
+}
+
+// bestPrices returns the best bid and ask prices; ok is false unless both
+// sides have levels
+func (o *OrderBookSnapshot) bestPrices() (bid, ask float64, ok bool) {
+	bestBid, hasBid := o.BestBid()
+	bestAsk, hasAsk := o.BestAsk()
+	if !hasBid || !hasAsk {
+		return 0, 0, false
+	}
+	return bestBid.Price, bestAsk.Price, true
+}
+
+// CalculateSpread sets and returns the best ask minus the best bid. A
+// one-sided book returns 0 and leaves Spread untouched
+func (o *OrderBookSnapshot) CalculateSpread() float64 {
+	bid, ask, ok := o.bestPrices()
+	if !ok {
+		return 0
+	}
+	o.Spread = ask - bid
+	return o.Spread
+}
+
+// CalculateMidPrice sets and returns the midpoint of the best bid and ask. A
+// one-sided book returns 0 and leaves MidPrice untouched
+func (o *OrderBookSnapshot) CalculateMidPrice() float64 {
+	bid, ask, ok := o.bestPrices()
+	if !ok {
+		return 0
+	}
+	o.MidPrice = (bid + ask) / 2
+	return o.MidPrice
+}
+
+// CalculateImbalance sets and returns (bidVol - askVol) / (bidVol + askVol)
+// over the top depth levels of each side, in [-1, 1]. A depth of 0 or less
+// uses every level; an empty book returns 0
+func (o *OrderBookSnapshot) CalculateImbalance(depth int) float64 {
+	bidVol := topDepthVolume(o.Bids, depth, func(a, b float64) bool { return a > b })
+	askVol := topDepthVolume(o.Asks, depth, func(a, b float64) bool { return a < b })
+
+	total := bidVol + askVol
+	if total <= 0 {
+		o.Imbalance = 0
+		return 0
+	}
+
+	imbalance := (bidVol - askVol) / total
+	if imbalance > 1 {
+		imbalance = 1
+	} else if imbalance < -1 {
+		imbalance = -1
+	}
+	o.Imbalance = imbalance
+	return imbalance
+}
+
+// sortedBookSide returns a copy of levels ordered best price first, where
+// better orders prices best first
+func sortedBookSide(levels []PriceLevel, better func(a, b float64) bool) []PriceLevel {
+	sorted := append([]PriceLevel(nil), levels...)
+	sort.SliceStable(sorted, func(i, j int) bool { return better(sorted[i].Price, sorted[j].Price) })
+	return sorted
+}
+
+// topDepthVolume sums the quantity of the best depth levels, where better
+// orders prices best first
+func topDepthVolume(levels []PriceLevel, depth int, better func(a, b float64) bool) float64 {
+	sorted := sortedBookSide(levels, better)
+	if depth > 0 && depth < len(sorted) {
+		sorted = sorted[:depth]
+	}
+
+	var volume float64
+	for _, level := range sorted {
+		volume += level.Quantity
+	}
+	return volume
+}
+
+// VWAP returns the volume-weighted average price of filling notionalUSD
+// against the book, walking bids best first for SideSell and asks best first
+// for SideBuy. Position sides are rejected; map a liquidated side with
+// Side.OrderSide first. filled is the USD notional actually fillable, which
+// is less than notionalUSD when the book is too thin
+func (o *OrderBookSnapshot) VWAP(side Side, notionalUSD float64) (vwap float64, filled float64, err error) {
+	if notionalUSD <= 0 {
+		return 0, 0, fmt.Errorf("invalid notional: %v", notionalUSD)
+	}
+
+	var levels []PriceLevel
+	switch side {
+	case SideSell:
+		if len(o.Bids) == 0 {
+			return 0, 0, fmt.Errorf("no bids to fill sell")
+		}
+		levels = sortedBookSide(o.Bids, func(a, b float64) bool { return a > b })
+	case SideBuy:
+		if len(o.Asks) == 0 {
+			return 0, 0, fmt.Errorf("no asks to fill buy")
+		}
+		levels = sortedBookSide(o.Asks, func(a, b float64) bool { return a < b })
+	default:
+		return 0, 0, fmt.Errorf("invalid order side: %q", side)
+	}
+
+	var quantity float64
+	for _, level := range levels {
+		if level.Price <= 0 || level.Quantity <= 0 {
+			continue
+		}
+		take := math.Min(level.Price*level.Quantity, notionalUSD-filled)
+		filled += take
+		quantity += take / level.Price
+		if filled >= notionalUSD {
+			break
+		}
+	}
+
+	if quantity <= 0 {
+		return 0, 0, nil
+	}
+	return filled / quantity, filled, nil
+}
@@ -0,0 +1,294 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func testOrderBook() OrderBookSnapshot {
+	return OrderBookSnapshot{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: 1234567890,
+		// Deliberately unsorted
+		Bids: []PriceLevel{
+			{Price: 44990.0, Quantity: 2.0},
+			{Price: 44999.0, Quantity: 1.0},
+			{Price: 44980.0, Quantity: 5.0},
+		},
+		Asks: []PriceLevel{
+			{Price: 45010.0, Quantity: 3.0},
+			{Price: 45001.0, Quantity: 1.5},
+			{Price: 45020.0, Quantity: 4.0},
+		},
+	}
+}
+
+func TestOrderBookClone(t *testing.T) {
+	book := testOrderBook()
+	original := testOrderBook()
+
+	clone := book.Clone()
+	clone.Bids[0].Price = 1.0
+	clone.Asks = append(clone.Asks[:0], PriceLevel{Price: 2.0, Quantity: 1.0})
+	clone.Symbol = SymbolETHUSDT
+
+	if !reflect.DeepEqual(book, original) {
+		t.Errorf("Clone() mutation leaked into source: %+v", book)
+	}
+}
+
+func TestCalculateSpreadAndMidPrice(t *testing.T) {
+	book := testOrderBook()
+
+	if spread := book.CalculateSpread(); spread != 2.0 {
+		t.Errorf("CalculateSpread() = %v, expected 2", spread)
+	}
+	if book.Spread != 2.0 {
+		t.Errorf("Spread field = %v, expected 2", book.Spread)
+	}
+
+	if mid := book.CalculateMidPrice(); mid != 45000.0 {
+		t.Errorf("CalculateMidPrice() = %v, expected 45000", mid)
+	}
+	if book.MidPrice != 45000.0 {
+		t.Errorf("MidPrice field = %v, expected 45000", book.MidPrice)
+	}
+}
+
+func TestCalculateSpreadOneSidedBook(t *testing.T) {
+	book := testOrderBook()
+	book.Asks = nil
+	book.Spread = 7.0
+	book.MidPrice = 123.0
+
+	if spread := book.CalculateSpread(); spread != 0 {
+		t.Errorf("CalculateSpread() on one-sided book = %v, expected 0", spread)
+	}
+	if mid := book.CalculateMidPrice(); mid != 0 {
+		t.Errorf("CalculateMidPrice() on one-sided book = %v, expected 0", mid)
+	}
+	if book.Spread != 7.0 || book.MidPrice != 123.0 {
+		t.Errorf("one-sided book fields changed to %v/%v", book.Spread, book.MidPrice)
+	}
+}
+
+func TestCalculateImbalance(t *testing.T) {
+	symmetric := OrderBookSnapshot{
+		Bids: []PriceLevel{{Price: 99.0, Quantity: 1.0}, {Price: 98.0, Quantity: 2.0}},
+		Asks: []PriceLevel{{Price: 101.0, Quantity: 1.0}, {Price: 102.0, Quantity: 2.0}},
+	}
+	if result := symmetric.CalculateImbalance(0); result != 0 {
+		t.Errorf("symmetric CalculateImbalance() = %v, expected 0", result)
+	}
+
+	book := testOrderBook() // bids 8.0, asks 8.5 in total
+	tests := []struct {
+		name     string
+		depth    int
+		expected float64
+	}{
+		// Top bid 44999 (1.0) vs top ask 45001 (1.5)
+		{name: "top of book", depth: 1, expected: (1.0 - 1.5) / 2.5},
+		// Top two bids 3.0 vs top two asks 4.5
+		{name: "depth two", depth: 2, expected: (3.0 - 4.5) / 7.5},
+		{name: "all levels", depth: 0, expected: (8.0 - 8.5) / 16.5},
+		{name: "depth beyond book", depth: 10, expected: (8.0 - 8.5) / 16.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := book.CalculateImbalance(tt.depth)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("CalculateImbalance(%d) = %v, expected %v", tt.depth, result, tt.expected)
+			}
+			if book.Imbalance != result {
+				t.Errorf("Imbalance field = %v, expected %v", book.Imbalance, result)
+			}
+		})
+	}
+
+	bidHeavy := OrderBookSnapshot{
+		Bids: []PriceLevel{{Price: 99.0, Quantity: 9.0}},
+		Asks: []PriceLevel{{Price: 101.0, Quantity: 1.0}},
+	}
+	if result := bidHeavy.CalculateImbalance(0); result <= 0 {
+		t.Errorf("bid-heavy CalculateImbalance() = %v, expected positive", result)
+	}
+
+	empty := OrderBookSnapshot{}
+	if result := empty.CalculateImbalance(5); result != 0 {
+		t.Errorf("empty CalculateImbalance() = %v, expected 0", result)
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	book := OrderBookSnapshot{
+		Bids: []PriceLevel{{Price: 99.0, Quantity: 2.0}, {Price: 100.0, Quantity: 1.0}, {Price: 98.0, Quantity: 5.0}},
+		Asks: []PriceLevel{{Price: 102.0, Quantity: 2.0}, {Price: 101.0, Quantity: 1.0}},
+	}
+
+	tests := []struct {
+		name         string
+		side         Side
+		notional     float64
+		expectedVWAP float64
+		expectedFill float64
+	}{
+		{"sell fills top two bids", SideSell, 298.0, 298.0 / 3, 298.0},
+		{"long liquidation sells", SideLong.OrderSide(), 100.0, 100.0, 100.0},
+		{"sell partial fill on thin book", SideSell, 1000.0, 788.0 / 8, 788.0},
+		{"buy within best ask", SideBuy, 50.0, 101.0, 50.0},
+		{"buy walks asks", SideBuy, 305.0, 305.0 / 3, 305.0},
+		{"short liquidation buys", SideShort.OrderSide(), 50.0, 101.0, 50.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vwap, filled, err := book.VWAP(tt.side, tt.notional)
+			if err != nil {
+				t.Fatalf("VWAP() error = %v", err)
+			}
+			if math.Abs(vwap-tt.expectedVWAP) > 1e-9 {
+				t.Errorf("VWAP() vwap = %v, expected %v", vwap, tt.expectedVWAP)
+			}
+			if math.Abs(filled-tt.expectedFill) > 1e-9 {
+				t.Errorf("VWAP() filled = %v, expected %v", filled, tt.expectedFill)
+			}
+		})
+	}
+
+	oneSided := OrderBookSnapshot{Bids: book.Bids}
+	if _, _, err := oneSided.VWAP(SideBuy, 100.0); err == nil {
+		t.Error("VWAP() buy against empty asks: expected error")
+	}
+	if _, _, err := book.VWAP("sideways", 100.0); err == nil {
+		t.Error("VWAP() with invalid side: expected error")
+	}
+	if _, _, err := book.VWAP(SideLong, 100.0); err == nil {
+		t.Error("VWAP() with a position side: expected error")
+	}
+}
+
+func TestBestBidAndAsk(t *testing.T) {
+	book := testOrderBook()
+
+	bid, ok := book.BestBid()
+	if !ok || bid != (PriceLevel{Price: 44999.0, Quantity: 1.0}) {
+		t.Errorf("BestBid() = %+v, %v, expected {44999 1}, true", bid, ok)
+	}
+	ask, ok := book.BestAsk()
+	if !ok || ask != (PriceLevel{Price: 45001.0, Quantity: 1.5}) {
+		t.Errorf("BestAsk() = %+v, %v, expected {45001 1.5}, true", ask, ok)
+	}
+
+	empty := OrderBookSnapshot{}
+	if _, ok := empty.BestBid(); ok {
+		t.Error("BestBid() on empty book: expected ok = false")
+	}
+	if _, ok := empty.BestAsk(); ok {
+		t.Error("BestAsk() on empty book: expected ok = false")
+	}
+}
+
+func TestOrderBookSort(t *testing.T) {
+	book := testOrderBook()
+	book.Bids = append(book.Bids, PriceLevel{Price: 44990.0, Quantity: 7.0}) // duplicate price
+	if book.IsSorted() {
+		t.Fatal("IsSorted() = true for shuffled book, expected false")
+	}
+
+	book.Sort()
+	if !book.IsSorted() {
+		t.Fatal("IsSorted() = false after Sort(), expected true")
+	}
+
+	expectedBids := []PriceLevel{
+		{Price: 44999.0, Quantity: 1.0},
+		{Price: 44990.0, Quantity: 2.0},
+		{Price: 44990.0, Quantity: 7.0},
+		{Price: 44980.0, Quantity: 5.0},
+	}
+	if !reflect.DeepEqual(book.Bids, expectedBids) {
+		t.Errorf("Bids after Sort() = %v, expected %v", book.Bids, expectedBids)
+	}
+	expectedAsks := []PriceLevel{
+		{Price: 45001.0, Quantity: 1.5},
+		{Price: 45010.0, Quantity: 3.0},
+		{Price: 45020.0, Quantity: 4.0},
+	}
+	if !reflect.DeepEqual(book.Asks, expectedAsks) {
+		t.Errorf("Asks after Sort() = %v, expected %v", book.Asks, expectedAsks)
+	}
+
+	if bid, _ := book.BestBid(); bid != book.Bids[0] {
+		t.Errorf("BestBid() = %v, expected Bids[0] %v", bid, book.Bids[0])
+	}
+
+	empty := OrderBookSnapshot{}
+	empty.Sort()
+	if !empty.IsSorted() {
+		t.Error("IsSorted() = false for empty book, expected true")
+	}
+}
+
+func TestApplyDelta(t *testing.T) {
+	book := testOrderBook()
+	book.LastUpdateID = 100
+
+	delta := OrderBookDelta{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: 1234567999,
+		Bids: []PriceLevel{
+			{Price: 44995.0, Quantity: 0.5}, // add
+			{Price: 44990.0, Quantity: 3.0}, // update
+			{Price: 44980.0, Quantity: 0},   // delete
+		},
+		Asks: []PriceLevel{
+			{Price: 45001.0, Quantity: 0},   // delete
+			{Price: 45005.0, Quantity: 2.5}, // add
+		},
+		LastUpdateID: 101,
+	}
+	if err := book.ApplyDelta(delta); err != nil {
+		t.Fatalf("ApplyDelta() error = %v", err)
+	}
+
+	expectedBids := []PriceLevel{
+		{Price: 44999.0, Quantity: 1.0},
+		{Price: 44995.0, Quantity: 0.5},
+		{Price: 44990.0, Quantity: 3.0},
+	}
+	if !reflect.DeepEqual(book.Bids, expectedBids) {
+		t.Errorf("Bids after ApplyDelta() = %v, expected %v", book.Bids, expectedBids)
+	}
+	expectedAsks := []PriceLevel{
+		{Price: 45005.0, Quantity: 2.5},
+		{Price: 45010.0, Quantity: 3.0},
+		{Price: 45020.0, Quantity: 4.0},
+	}
+	if !reflect.DeepEqual(book.Asks, expectedAsks) {
+		t.Errorf("Asks after ApplyDelta() = %v, expected %v", book.Asks, expectedAsks)
+	}
+	if book.LastUpdateID != 101 || book.Timestamp != 1234567999 {
+		t.Errorf("LastUpdateID/Timestamp = %v/%v, expected 101/1234567999", book.LastUpdateID, book.Timestamp)
+	}
+
+	stale := delta
+	stale.LastUpdateID = 101
+	stale.Bids = []PriceLevel{{Price: 44999.0, Quantity: 0}}
+	if err := book.ApplyDelta(stale); err == nil {
+		t.Error("ApplyDelta() with stale update id: expected error")
+	}
+	if !reflect.DeepEqual(book.Bids, expectedBids) {
+		t.Errorf("rejected delta modified Bids: %v", book.Bids)
+	}
+
+	otherMarket := delta
+	otherMarket.Symbol = SymbolETHUSDT
+	otherMarket.LastUpdateID = 200
+	if err := book.ApplyDelta(otherMarket); err == nil {
+		t.Error("ApplyDelta() for another symbol: expected error")
+	}
+}
@@ -0,0 +1,249 @@
+package models
+
+import (
+	"github.com/bohunn/gort-trade-model/proto/modelspb"
+)
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/bohunn/gort-trade-model ../proto/models.proto
+
+// ===========================================
+// PROTOBUF CONVERSIONS
+// ===========================================
+
+// The wire types are generated into proto/modelspb from proto/models.proto,
+// where field numbers are documented and must stay stable. Exchange and
+// symbol travel as strings, so runtime-registered values survive the wire
+
+// Enum tables map proto enum numbers (the index) to model values. Values
+// missing from a table encode as 0 (UNSPECIFIED) and decode as the empty value
+var (
+	protoSides         = []Side{"", SideLong, SideShort, SideBuy, SideSell}
+	protoOrderTypes    = []OrderType{"", OrderTypeLiquidation, OrderTypeADL, OrderTypeBankruptcy}
+	protoContractTypes = []ContractType{"", ContractLinear, ContractInverse}
+	protoIntervals     = []Interval{"", Interval1s, Interval1m, Interval5m, Interval15m,
+		Interval1h, Interval4h, Interval1d, Interval1w}
+)
+
+// toProtoEnum returns the proto enum number of v, or 0 when v is not in values
+func toProtoEnum[E ~int32, T comparable](values []T, v T) E {
+	for i, value := range values {
+		if value == v {
+			return E(i)
+		}
+	}
+	return 0
+}
+
+// fromProtoEnum returns the model value of proto enum number n
+func fromProtoEnum[E ~int32, T any](values []T, n E) T {
+	if n < 0 || int(n) >= len(values) {
+		var zero T
+		return zero
+	}
+	return values[n]
+}
+
+// ToProto converts the event to its wire form
+func (l *LiquidationEvent) ToProto() *modelspb.LiquidationEvent {
+	return &modelspb.LiquidationEvent{
+		Exchange:       string(l.Exchange),
+		Symbol:         string(l.Symbol),
+		Timestamp:      l.Timestamp,
+		Side:           toProtoEnum[modelspb.Side](protoSides, l.Side),
+		Price:          l.Price,
+		Quantity:       l.Quantity,
+		Value:          l.Value,
+		OrderType:      toProtoEnum[modelspb.OrderType](protoOrderTypes, l.OrderType),
+		AvgPrice:       l.AvgPrice,
+		FilledQty:      l.FilledQty,
+		OrderStatus:    l.OrderStatus,
+		OrderTradeTime: l.OrderTradeTime,
+		ContractType:   toProtoEnum[modelspb.ContractType](protoContractTypes, l.ContractType),
+		ContractSize:   l.ContractSize,
+	}
+}
+
+// FromProto replaces the event with the contents of pb
+func (l *LiquidationEvent) FromProto(pb *modelspb.LiquidationEvent) {
+	*l = LiquidationEvent{
+		Exchange:       Exchange(pb.Exchange),
+		Symbol:         Symbol(pb.Symbol),
+		Timestamp:      pb.Timestamp,
+		Side:           fromProtoEnum(protoSides, pb.Side),
+		Price:          pb.Price,
+		Quantity:       pb.Quantity,
+		Value:          pb.Value,
+		OrderType:      fromProtoEnum(protoOrderTypes, pb.OrderType),
+		AvgPrice:       pb.AvgPrice,
+		FilledQty:      pb.FilledQty,
+		OrderStatus:    pb.OrderStatus,
+		OrderTradeTime: pb.OrderTradeTime,
+		ContractType:   fromProtoEnum(protoContractTypes, pb.ContractType),
+		ContractSize:   pb.ContractSize,
+	}
+}
+
+// ToProto converts the snapshot to its wire form
+func (m *MarketSnapshot) ToProto() *modelspb.MarketSnapshot {
+	return &modelspb.MarketSnapshot{
+		Exchange:        string(m.Exchange),
+		Symbol:          string(m.Symbol),
+		Timestamp:       m.Timestamp,
+		MarkPrice:       m.MarkPrice,
+		IndexPrice:      m.IndexPrice,
+		FundingRate:     m.FundingRate,
+		OpenInterest:    m.OpenInterest,
+		OpenInterestUsd: m.OpenInterestUSD,
+		Volume_24H:      m.Volume24h,
+		Turnover_24H:    m.Turnover24h,
+		NextFundingTime: m.NextFundingTime,
+	}
+}
+
+// FromProto replaces the snapshot with the contents of pb
+func (m *MarketSnapshot) FromProto(pb *modelspb.MarketSnapshot) {
+	*m = MarketSnapshot{
+		Exchange:        Exchange(pb.Exchange),
+		Symbol:          Symbol(pb.Symbol),
+		Timestamp:       pb.Timestamp,
+		MarkPrice:       pb.MarkPrice,
+		IndexPrice:      pb.IndexPrice,
+		FundingRate:     pb.FundingRate,
+		OpenInterest:    pb.OpenInterest,
+		OpenInterestUSD: pb.OpenInterestUsd,
+		Volume24h:       pb.Volume_24H,
+		Turnover24h:     pb.Turnover_24H,
+		NextFundingTime: pb.NextFundingTime,
+	}
+}
+
+// ToProto converts the heatmap to its wire form
+func (h *HeatmapData) ToProto() *modelspb.HeatmapData {
+	pb := &modelspb.HeatmapData{
+		Symbol:       string(h.Symbol),
+		Exchange:     string(h.Exchange),
+		Timestamp:    h.Timestamp,
+		Interval:     toProtoEnum[modelspb.Interval](protoIntervals, h.Interval),
+		CurrentPrice: h.CurrentPrice,
+		Levels:       levelsToProto(h.Levels),
+		Summary: &modelspb.HeatmapSummary{
+			TotalLongLiquidations:  h.Summary.TotalLongLiquidations,
+			TotalShortLiquidations: h.Summary.TotalShortLiquidations,
+			MaxLiquidationPrice:    h.Summary.MaxLiquidationPrice,
+			MaxLiquidationVolume:   h.Summary.MaxLiquidationVolume,
+			WeightedAvgLongPrice:   h.Summary.WeightedAvgLongPrice,
+			WeightedAvgShortPrice:  h.Summary.WeightedAvgShortPrice,
+			SignificantLevels:      int64(h.Summary.SignificantLevels),
+		},
+	}
+	for _, c := range h.Clusters {
+		pb.Clusters = append(pb.Clusters, &modelspb.LiquidationCluster{
+			Symbol:          string(c.Symbol),
+			PriceRangeStart: c.PriceRangeStart,
+			PriceRangeEnd:   c.PriceRangeEnd,
+			Levels:          levelsToProto(c.Levels),
+			TotalVolume:     c.TotalVolume,
+			PeakIntensity:   c.PeakIntensity,
+			UpdatedAt:       c.UpdatedAt,
+		})
+	}
+	for _, z := range h.Summary.CriticalZones {
+		pb.Summary.CriticalZones = append(pb.Summary.CriticalZones, &modelspb.CriticalZone{
+			PriceStart: z.PriceStart,
+			PriceEnd:   z.PriceEnd,
+			Type:       z.Type,
+			Intensity:  z.Intensity,
+			Volume:     z.Volume,
+		})
+	}
+	return pb
+}
+
+// FromProto replaces the heatmap with the contents of pb
+func (h *HeatmapData) FromProto(pb *modelspb.HeatmapData) {
+	*h = HeatmapData{
+		Symbol:       Symbol(pb.Symbol),
+		Exchange:     Exchange(pb.Exchange),
+		Timestamp:    pb.Timestamp,
+		Interval:     fromProtoEnum(protoIntervals, pb.Interval),
+		CurrentPrice: pb.CurrentPrice,
+		Levels:       levelsFromProto(pb.Levels),
+	}
+	for _, c := range pb.Clusters {
+		h.Clusters = append(h.Clusters, LiquidationCluster{
+			Symbol:          Symbol(c.Symbol),
+			PriceRangeStart: c.PriceRangeStart,
+			PriceRangeEnd:   c.PriceRangeEnd,
+			Levels:          levelsFromProto(c.Levels),
+			TotalVolume:     c.TotalVolume,
+			PeakIntensity:   c.PeakIntensity,
+			UpdatedAt:       c.UpdatedAt,
+		})
+	}
+	if s := pb.Summary; s != nil {
+		h.Summary = HeatmapSummary{
+			TotalLongLiquidations:  s.TotalLongLiquidations,
+			TotalShortLiquidations: s.TotalShortLiquidations,
+			MaxLiquidationPrice:    s.MaxLiquidationPrice,
+			MaxLiquidationVolume:   s.MaxLiquidationVolume,
+			WeightedAvgLongPrice:   s.WeightedAvgLongPrice,
+			WeightedAvgShortPrice:  s.WeightedAvgShortPrice,
+			SignificantLevels:      int(s.SignificantLevels),
+		}
+		for _, z := range s.CriticalZones {
+			h.Summary.CriticalZones = append(h.Summary.CriticalZones, CriticalZone{
+				PriceStart: z.PriceStart,
+				PriceEnd:   z.PriceEnd,
+				Type:       z.Type,
+				Intensity:  z.Intensity,
+				Volume:     z.Volume,
+			})
+		}
+	}
+}
+
+func levelsToProto(levels []LiquidationLevel) []*modelspb.LiquidationLevel {
+	var pbs []*modelspb.LiquidationLevel
+	for _, l := range levels {
+		pbs = append(pbs, &modelspb.LiquidationLevel{
+			Price:             l.Price,
+			LongLiquidations:  l.LongLiquidations,
+			ShortLiquidations: l.ShortLiquidations,
+			TotalVolume:       l.TotalVolume,
+			Intensity:         l.Intensity,
+			WeightedIntensity: l.WeightedIntensity,
+			RelativeIntensity: l.RelativeIntensity,
+			PriceLow:          l.PriceLow,
+			PriceHigh:         l.PriceHigh,
+			OldestTimestamp:   l.OldestTimestamp,
+			NewestTimestamp:   l.NewestTimestamp,
+			Timestamp:         l.Timestamp,
+			OiFraction:        l.OIFraction,
+			DistancePct:       l.DistancePct,
+		})
+	}
+	return pbs
+}
+
+func levelsFromProto(pbs []*modelspb.LiquidationLevel) []LiquidationLevel {
+	var levels []LiquidationLevel
+	for _, pb := range pbs {
+		levels = append(levels, LiquidationLevel{
+			Price:             pb.Price,
+			LongLiquidations:  pb.LongLiquidations,
+			ShortLiquidations: pb.ShortLiquidations,
+			TotalVolume:       pb.TotalVolume,
+			Intensity:         pb.Intensity,
+			WeightedIntensity: pb.WeightedIntensity,
+			RelativeIntensity: pb.RelativeIntensity,
+			PriceLow:          pb.PriceLow,
+			PriceHigh:         pb.PriceHigh,
+			OldestTimestamp:   pb.OldestTimestamp,
+			NewestTimestamp:   pb.NewestTimestamp,
+			Timestamp:         pb.Timestamp,
+			OIFraction:        pb.OiFraction,
+			DistancePct:       pb.DistancePct,
+		})
+	}
+	return levels
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bohunn/gort-trade-model/proto/modelspb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLiquidationEventProtoRoundTrip(t *testing.T) {
+	event := LiquidationEvent{
+		Exchange:       ExchangeDeribit,
+		Symbol:         SymbolBTCUSDT,
+		Timestamp:      1700000000123,
+		Side:           SideSell,
+		Price:          45000.5,
+		Quantity:       900,
+		Value:          -1, // negative values survive the wire
+		OrderType:      OrderTypeADL,
+		AvgPrice:       44999.0,
+		FilledQty:      900,
+		OrderStatus:    "FILLED",
+		OrderTradeTime: 1700000000124,
+		ContractType:   ContractInverse,
+		ContractSize:   10,
+	}
+
+	data, err := proto.Marshal(event.ToProto())
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	var decoded modelspb.LiquidationEvent
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	var result LiquidationEvent
+	result.FromProto(&decoded)
+	if !reflect.DeepEqual(result, event) {
+		t.Errorf("round trip = %+v, expected %+v", result, event)
+	}
+}
+
+func TestMarketSnapshotProtoRoundTrip(t *testing.T) {
+	snapshot := MarketSnapshot{
+		Exchange:        ExchangeOKX,
+		Symbol:          SymbolETHUSDT,
+		Timestamp:       1700000000123,
+		MarkPrice:       2500.25,
+		IndexPrice:      2500.1,
+		FundingRate:     -0.0001,
+		OpenInterest:    120000.0,
+		OpenInterestUSD: 300030000.0,
+		Volume24h:       1e9,
+		Turnover24h:     2e9,
+		NextFundingTime: 1700003600000,
+	}
+
+	data, err := proto.Marshal(snapshot.ToProto())
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	var decoded modelspb.MarketSnapshot
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	var result MarketSnapshot
+	result.FromProto(&decoded)
+	if !reflect.DeepEqual(result, snapshot) {
+		t.Errorf("round trip = %+v, expected %+v", result, snapshot)
+	}
+}
+
+func TestHeatmapDataProtoRoundTrip(t *testing.T) {
+	level := LiquidationLevel{
+		Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0, Intensity: 100.0,
+		WeightedIntensity: 80.0, RelativeIntensity: 150.0, PriceLow: 43950.0, PriceHigh: 44050.0,
+		OldestTimestamp: 1000, NewestTimestamp: 2000, Timestamp: 2000, OIFraction: 0.04, DistancePct: -2.2,
+	}
+	heatmap := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Exchange:     ExchangeBybit,
+		Timestamp:    1700000000123,
+		Interval:     Interval1w,
+		CurrentPrice: 45000.0,
+		Levels:       []LiquidationLevel{level, {Price: 46000.0, ShortLiquidations: 5000.0, TotalVolume: 5000.0}},
+		Clusters: []LiquidationCluster{
+			{Symbol: SymbolBTCUSDT, PriceRangeStart: 44000.0, PriceRangeEnd: 44000.0, Levels: []LiquidationLevel{level},
+				TotalVolume: 100000.0, PeakIntensity: 100.0, UpdatedAt: 1700000000123},
+		},
+		Summary: HeatmapSummary{
+			TotalLongLiquidations:  100000.0,
+			TotalShortLiquidations: 5000.0,
+			MaxLiquidationPrice:    44000.0,
+			MaxLiquidationVolume:   100000.0,
+			WeightedAvgLongPrice:   44000.0,
+			WeightedAvgShortPrice:  46000.0,
+			SignificantLevels:      1,
+			CriticalZones:          []CriticalZone{{PriceStart: 44000.0, PriceEnd: 44000.0, Type: "long", Intensity: 100.0, Volume: 100000.0}},
+		},
+	}
+
+	data, err := proto.Marshal(heatmap.ToProto())
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	var decoded modelspb.HeatmapData
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	var result HeatmapData
+	result.FromProto(&decoded)
+	if !reflect.DeepEqual(result, heatmap) {
+		t.Errorf("round trip = %+v, expected %+v", result, heatmap)
+	}
+}
+
+func TestProtoRegisteredExchange(t *testing.T) {
+	RegisterExchange("hyperliquid")
+	t.Cleanup(func() { unregisterExchange("hyperliquid") })
+
+	event := LiquidationEvent{Exchange: "hyperliquid", Symbol: "HYPEUSDT", Side: SideLong, Price: 30.5}
+	data, err := proto.Marshal(event.ToProto())
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	var decoded modelspb.LiquidationEvent
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	var result LiquidationEvent
+	result.FromProto(&decoded)
+	if !reflect.DeepEqual(result, event) {
+		t.Errorf("round trip = %+v, expected %+v", result, event)
+	}
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ===========================================
+// EXCHANGE AND SYMBOL REGISTRY
+// ===========================================
+
+var (
+	registryMu         sync.RWMutex
+	registeredExchange = make(map[Exchange]bool)
+	registeredSymbol   = make(map[Symbol]bool)
+)
+
+func init() {
+	for _, exchange := range []Exchange{
+		ExchangeBinance, ExchangeOKX, ExchangeBybit, ExchangeCoinbase,
+		ExchangeKraken, ExchangeDeribit, ExchangeBitfinex,
+	} {
+		RegisterExchange(string(exchange))
+	}
+	for _, symbol := range []Symbol{
+		SymbolBTCUSDT, SymbolETHUSDT, SymbolBNBUSDT, SymbolSOLUSDT, SymbolXRPUSDT,
+	} {
+		RegisterSymbol(string(symbol))
+	}
+}
+
+// RegisterExchange adds an exchange, stored lowercase, to the set accepted by
+// Exchange.IsValid and ParseExchange. Empty names are ignored
+func RegisterExchange(name string) {
+	exchange := Exchange(strings.ToLower(strings.TrimSpace(name)))
+	if exchange == "" {
+		return
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registeredExchange[exchange] = true
+}
+
+// RegisterSymbol adds a trading pair, stored uppercase, to the set accepted
+// by Symbol.IsValid and ParseSymbol. Empty symbols are ignored
+func RegisterSymbol(s string) {
+	symbol := Symbol(strings.ToUpper(strings.TrimSpace(s)))
+	if symbol == "" {
+		return
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registeredSymbol[symbol] = true
+}
+
+// unregisterExchange removes an exchange from the registry. It exists so
+// tests can undo RegisterExchange
+func unregisterExchange(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registeredExchange, Exchange(strings.ToLower(strings.TrimSpace(name))))
+}
+
+// unregisterSymbol removes a trading pair from the registry. It exists so
+// tests can undo RegisterSymbol
+func unregisterSymbol(s string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registeredSymbol, Symbol(strings.ToUpper(strings.TrimSpace(s))))
+}
+
+// isRegisteredExchange reports whether the exchange is in the registry
+func isRegisteredExchange(exchange Exchange) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registeredExchange[exchange]
+}
+
+// isRegisteredSymbol reports whether the symbol is in the registry
+func isRegisteredSymbol(symbol Symbol) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registeredSymbol[symbol]
+}
+
+// registeredNames returns the registered exchanges and symbols, each sorted
+func registeredNames() (exchanges, symbols []string) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for exchange := range registeredExchange {
+		exchanges = append(exchanges, string(exchange))
+	}
+	for symbol := range registeredSymbol {
+		symbols = append(symbols, string(symbol))
+	}
+	sort.Strings(exchanges)
+	sort.Strings(symbols)
+	return exchanges, symbols
+}
+
+// ParseSymbol normalizes a symbol to upper case and checks it against the
+// registry
+func ParseSymbol(s string) (Symbol, error) {
+	symbol := Symbol(strings.ToUpper(strings.TrimSpace(s)))
+	if !symbol.IsValid() {
+		return "", fmt.Errorf("unknown symbol: %q", s)
+	}
+	return symbol, nil
+}
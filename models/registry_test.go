@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestRegisterSymbol(t *testing.T) {
+	if Symbol("ARBUSDT").IsValid() {
+		t.Fatal("ARBUSDT should not be valid before registration")
+	}
+	if _, err := ParseSymbol("arbusdt"); err == nil {
+		t.Error("ParseSymbol(\"arbusdt\") before registration: expected error")
+	}
+
+	RegisterSymbol(" arbusdt ")
+	t.Cleanup(func() { unregisterSymbol("ARBUSDT") })
+
+	if !Symbol("ARBUSDT").IsValid() {
+		t.Error("Symbol(\"ARBUSDT\").IsValid() = false after registration, expected true")
+	}
+	result, err := ParseSymbol("ArbUSDT")
+	if err != nil || result != "ARBUSDT" {
+		t.Errorf("ParseSymbol(\"ArbUSDT\") = %v, %v, expected ARBUSDT", result, err)
+	}
+
+	if _, err := ParseSymbol("PEPEUSDT"); err == nil {
+		t.Error("ParseSymbol(\"PEPEUSDT\") of unregistered symbol: expected error")
+	}
+	if !SymbolBTCUSDT.IsValid() {
+		t.Error("built-in symbols should be pre-registered")
+	}
+}
+
+func TestRegisterExchange(t *testing.T) {
+	if _, err := ParseExchange("Hyperliquid"); err == nil {
+		t.Fatal("ParseExchange(\"Hyperliquid\") before registration: expected error")
+	}
+
+	RegisterExchange("Hyperliquid")
+	t.Cleanup(func() { unregisterExchange("hyperliquid") })
+
+	result, err := ParseExchange("HYPERLIQUID")
+	if err != nil || result != "hyperliquid" {
+		t.Errorf("ParseExchange(\"HYPERLIQUID\") = %v, %v, expected hyperliquid", result, err)
+	}
+	if !Exchange("hyperliquid").IsValid() {
+		t.Error("Exchange(\"hyperliquid\").IsValid() = false after registration, expected true")
+	}
+	if Exchange("dydx").IsValid() {
+		t.Error("Exchange(\"dydx\").IsValid() = true for unregistered exchange, expected false")
+	}
+
+	RegisterExchange("  ")
+	if Exchange("").IsValid() {
+		t.Error("registering an empty name should be ignored")
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"sort"
+	"sync"
+)
+
+// ===========================================
+// HEATMAP STORE
+// ===========================================
+
+// HeatmapStore holds the latest heatmap per symbol and interval and is safe
+// for concurrent use. The zero value is ready to use
+type HeatmapStore struct {
+	mu       sync.RWMutex
+	heatmaps map[string]HeatmapData
+}
+
+// NewHeatmapStore creates an empty HeatmapStore
+func NewHeatmapStore() *HeatmapStore {
+	return &HeatmapStore{heatmaps: make(map[string]HeatmapData)}
+}
+
+// Set stores a copy of h, replacing any heatmap for the same symbol and interval
+func (s *HeatmapStore) Set(h HeatmapData) {
+	clone := h.Clone()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.heatmaps == nil {
+		s.heatmaps = make(map[string]HeatmapData)
+	}
+	s.heatmaps[GetHeatmapCacheKey(h.Symbol, h.Interval)] = clone
+}
+
+// Get returns a copy of the heatmap stored for symbol and interval, so the
+// caller may mutate it freely
+func (s *HeatmapStore) Get(symbol Symbol, interval Interval) (HeatmapData, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h, ok := s.heatmaps[GetHeatmapCacheKey(symbol, interval)]
+	if !ok {
+		return HeatmapData{}, false
+	}
+	return h.Clone(), true
+}
+
+// Keys returns the cache keys of all stored heatmaps, sorted
+func (s *HeatmapStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.heatmaps))
+	for key := range s.heatmaps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestHeatmapStore(t *testing.T) {
+	store := NewHeatmapStore()
+
+	if _, ok := store.Get(SymbolBTCUSDT, Interval1m); ok {
+		t.Error("Get() on empty store expected false")
+	}
+
+	heatmap := HeatmapData{
+		Symbol:   SymbolBTCUSDT,
+		Interval: Interval1m,
+		Levels:   []LiquidationLevel{{Price: 44000.0, TotalVolume: 100000.0}},
+	}
+	store.Set(heatmap)
+	store.Set(HeatmapData{Symbol: SymbolETHUSDT, Interval: Interval1h})
+
+	heatmap.Levels[0].TotalVolume = 0 // caller keeps mutating after Set
+
+	got, ok := store.Get(SymbolBTCUSDT, Interval1m)
+	if !ok || got.Levels[0].TotalVolume != 100000.0 {
+		t.Fatalf("Get() = %+v, %v, expected stored heatmap", got, ok)
+	}
+
+	got.Levels[0].TotalVolume = 1.0
+	if again, _ := store.Get(SymbolBTCUSDT, Interval1m); again.Levels[0].TotalVolume != 100000.0 {
+		t.Errorf("Get() returned shared levels, volume = %v", again.Levels[0].TotalVolume)
+	}
+
+	expected := []string{"heatmap:cache:BTCUSDT:1m", "heatmap:cache:ETHUSDT:1h"}
+	if keys := store.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Keys() = %v, expected %v", keys, expected)
+	}
+}
+
+func TestHeatmapStoreConcurrent(t *testing.T) {
+	var store HeatmapStore
+	symbols := []Symbol{SymbolBTCUSDT, SymbolETHUSDT, SymbolSOLUSDT}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				store.Set(HeatmapData{
+					Symbol:    symbols[(i+j)%len(symbols)],
+					Interval:  Interval1m,
+					Timestamp: int64(j),
+					Levels:    []LiquidationLevel{{Price: float64(j), TotalVolume: float64(i)}},
+				})
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if h, ok := store.Get(symbols[(i+j)%len(symbols)], Interval1m); ok {
+					h.Levels[0].TotalVolume = -1
+				}
+				store.Keys()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if keys := store.Keys(); len(keys) != len(symbols) {
+		t.Errorf("Keys() = %v, expected %d keys", keys, len(symbols))
+	}
+	for _, symbol := range symbols {
+		if h, ok := store.Get(symbol, Interval1m); !ok || h.Levels[0].TotalVolume < 0 {
+			t.Errorf("Get(%v) = %+v, %v, expected unmodified heatmap", symbol, h, ok)
+		}
+	}
+}
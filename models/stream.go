@@ -0,0 +1,488 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shamaton/msgpack/v2"
+)
+
+// ===========================================
+// STREAM WRITE HELPERS
+// ===========================================
+
+// ToStreamMessageMsgpack is ToStreamMessage with nested objects and arrays
+// encoded as MessagePack []byte values, through the models' msgpack tags,
+// instead of JSON strings. Top-level scalars are kept typed exactly as in
+// ToStreamMessage
+func ToStreamMessageMsgpack(streamName string, v interface{}) (*StreamMessage, error) {
+	// Nested fields are left nil here and packed from the struct fields below
+	data, err := structToMapNested(v, func(interface{}) (interface{}, error) { return nil, nil })
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("msgpack stream value must be a struct, got %T", v)
+	}
+	for name, index := range jsonFieldIndexes(rv.Type()) {
+		if value, ok := data[name]; !ok || value != nil {
+			continue
+		}
+		field, err := rv.FieldByIndexErr(index)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		packed, err := msgpack.Marshal(field.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		data[name] = packed
+	}
+
+	return &StreamMessage{
+		Stream:    streamName,
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	}, nil
+}
+
+// FromStreamMessage reverses ToStreamMessage and ToStreamMessageMsgpack,
+// decoding msg.Data into v, which must be a pointer to a struct. Stringified
+// scalars are coerced back to the field's type and nested fields flattened to
+// JSON strings or MessagePack bytes are parsed
+func FromStreamMessage(msg *StreamMessage, v interface{}) error {
+	if msg == nil {
+		return fmt.Errorf("stream message is nil")
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a non-nil pointer to a struct, got %T", v)
+	}
+
+	values := msg.Data
+	if msg.IsCompressed() {
+		decompressed, err := msg.decompressedData()
+		if err != nil {
+			return err
+		}
+		values = decompressed
+	}
+
+	fields := jsonFieldTypes(rv.Elem().Type())
+	raw := make(map[string]json.RawMessage, len(values))
+	for key, value := range values {
+		fieldType, ok := fields[key]
+		if !ok {
+			continue
+		}
+		encoded, err := encodeStreamField(value, fieldType)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+		raw[key] = encoded
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// DecodeByStream decodes Data into the type published on the message's
+// stream, chosen by the stream name prefix: *LiquidationEvent for
+// "liquidations", *MarketSnapshot for "market", *OrderBookSnapshot for
+// "orderbook" and *HeatmapData for "heatmap"
+func (s *StreamMessage) DecodeByStream() (interface{}, error) {
+	dataType, _, _, err := ParseStreamName(s.Stream)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	switch dataType {
+	case "liquidations":
+		v = &LiquidationEvent{}
+	case "market":
+		v = &MarketSnapshot{}
+	case "orderbook":
+		v = &OrderBookSnapshot{}
+	case "heatmap":
+		v = &HeatmapData{}
+	default:
+		return nil, fmt.Errorf("unknown stream data type %q", dataType)
+	}
+
+	if err := FromStreamMessage(s, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// encodeStreamField converts a flattened stream value back into the JSON
+// encoding expected by a field of type t
+func encodeStreamField(value interface{}, t reflect.Type) (json.RawMessage, error) {
+	if packed, ok := value.([]byte); ok {
+		// Nested field encoded by ToStreamMessageMsgpack
+		decoded := reflect.New(t)
+		if err := msgpack.Unmarshal(packed, decoded.Interface()); err != nil {
+			return nil, err
+		}
+		return json.Marshal(decoded.Interface())
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return json.Marshal(value)
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return json.Marshal(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// Large integers may have been rendered in exponent form
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(strconv.FormatFloat(f, 'f', -1, 64)), nil
+	default:
+		// Numbers, bools, and JSON-stringified nested values are valid JSON as-is
+		if !json.Valid([]byte(str)) {
+			return nil, fmt.Errorf("invalid value %q", str)
+		}
+		return json.RawMessage(str), nil
+	}
+}
+
+// jsonFieldTypes maps the top-level JSON field names of a struct type to
+// their Go types, following encoding/json tag and embedding rules
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for name, index := range jsonFieldIndexes(t) {
+		fields[name] = t.FieldByIndex(index).Type
+	}
+	return fields
+}
+
+// jsonFieldIndexes maps the top-level JSON field names of a struct type to
+// their field index paths, following encoding/json tag and embedding rules
+func jsonFieldIndexes(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, index := range jsonFieldIndexes(embedded) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = append([]int{i}, index...)
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = []int{i}
+	}
+	return fields
+}
+
+// Fingerprint returns a stable hash of the message payload. The stream
+// message ID and Timestamp are excluded so identical payloads published at
+// different times share a fingerprint
+func (s *StreamMessage) Fingerprint() string {
+	// encoding/json sorts map keys, so equal payloads marshal identically
+	data, err := json.Marshal(s.Data)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// XAddArgs returns the stream name, field map, and approximate MAXLEN cap to
+// pass to a Redis XADD call. The field map is a copy of Data so callers may
+// add fields without mutating the message
+func (s *StreamMessage) XAddArgs(maxLen int64) (stream string, values map[string]interface{}, approxMaxLen int64) {
+	values = make(map[string]interface{}, len(s.Data))
+	for k, v := range s.Data {
+		values[k] = v
+	}
+	return s.Stream, values, maxLen
+}
+
+// DedupWriter wraps an output function and drops messages whose payload is
+// unchanged since the last message written to the same stream. A message is
+// always written once MinInterval has elapsed since the last write so
+// consumers still see periodic refreshes during quiet markets
+type DedupWriter struct {
+	write       func(*StreamMessage) error
+	minInterval time.Duration
+	now         func() time.Time
+
+	mu   sync.Mutex
+	last map[string]dedupEntry
+}
+
+type dedupEntry struct {
+	fingerprint string
+	writtenAt   time.Time
+}
+
+// NewDedupWriter creates a DedupWriter; a zero minInterval never forces a refresh
+func NewDedupWriter(write func(*StreamMessage) error, minInterval time.Duration) *DedupWriter {
+	return &DedupWriter{
+		write:       write,
+		minInterval: minInterval,
+		now:         time.Now,
+		last:        make(map[string]dedupEntry),
+	}
+}
+
+// Write forwards msg unless it duplicates the last message sent on its stream.
+// It reports whether the message was written
+func (d *DedupWriter) Write(msg *StreamMessage) (bool, error) {
+	fingerprint := msg.Fingerprint()
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if last, ok := d.last[msg.Stream]; ok && last.fingerprint == fingerprint {
+		if d.minInterval <= 0 || now.Sub(last.writtenAt) < d.minInterval {
+			return false, nil
+		}
+	}
+
+	if err := d.write(msg); err != nil {
+		return false, err
+	}
+	d.last[msg.Stream] = dedupEntry{fingerprint: fingerprint, writtenAt: now}
+	return true, nil
+}
+
+// maxStreamLineSize bounds a single NDJSON line read by DecodeLiquidationStream
+const maxStreamLineSize = 1 << 20
+
+// DecodeLiquidationStream reads newline-delimited JSON events from r one line
+// at a time, emitting events that pass Validate on the first channel and
+// parse or validation errors, tagged with their line number, on the second.
+// Blank lines are skipped.
+//
+// Errors never block event delivery: they are queued until received, and the
+// events channel is closed at EOF before the queued errors are sent, so
+// callers may select on both channels or range over events and then drain
+// errs. Cancelling ctx stops decoding and closes both channels; a read
+// already blocked in r is not interrupted
+func DecodeLiquidationStream(ctx context.Context, r io.Reader) (<-chan LiquidationEvent, <-chan error) {
+	events := make(chan LiquidationEvent)
+	errs := make(chan error)
+
+	go func() {
+		defer close(errs)
+
+		var pending []error
+		// deliver sends event, handing queued errors to errs while it waits.
+		// It reports false when ctx is done
+		deliver := func(event LiquidationEvent) bool {
+			for {
+				var errCh chan<- error
+				var next error
+				if len(pending) > 0 {
+					errCh, next = errs, pending[0]
+				}
+				select {
+				case events <- event:
+					return true
+				case errCh <- next:
+					pending = pending[1:]
+				case <-ctx.Done():
+					return false
+				}
+			}
+		}
+
+		decode := func() bool {
+			scanner := bufio.NewScanner(r)
+			scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+			for line := 1; scanner.Scan(); line++ {
+				if ctx.Err() != nil {
+					return false
+				}
+				data := scanner.Bytes()
+				if len(strings.TrimSpace(string(data))) == 0 {
+					continue
+				}
+
+				var event LiquidationEvent
+				if err := json.Unmarshal(data, &event); err != nil {
+					pending = append(pending, fmt.Errorf("line %d: %w", line, err))
+					continue
+				}
+				if err := event.Validate(); err != nil {
+					pending = append(pending, fmt.Errorf("line %d: %w", line, err))
+					continue
+				}
+				if !deliver(event) {
+					return false
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				pending = append(pending, err)
+			}
+			return true
+		}
+
+		completed := decode()
+		close(events)
+		if !completed {
+			return
+		}
+		for _, err := range pending {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, errs
+}
+
+// ===========================================
+// STREAM COMPRESSION
+// ===========================================
+
+// CompressedDataField is the single Data field holding the gzip payload of a
+// compressed StreamMessage
+const CompressedDataField = "gzip"
+
+// CompressStreamData gzips the JSON encoding of a stream data map
+func CompressStreamData(data map[string]interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(encoded); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecompressStreamData reverses CompressStreamData. Numbers decode as float64
+// as with any JSON-decoded map
+func DecompressStreamData(compressed []byte) (map[string]interface{}, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip payload: %w", err)
+	}
+	defer zr.Close()
+
+	var data map[string]interface{}
+	if err := json.NewDecoder(zr).Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid compressed stream data: %w", err)
+	}
+	return data, nil
+}
+
+// Compress replaces Data with a single gzip payload under CompressedDataField
+// when its JSON encoding is at least threshold bytes, and reports whether it
+// did. Intended for messages built by ToStreamMessage; already compressed
+// messages are left as is
+func (s *StreamMessage) Compress(threshold int) (bool, error) {
+	if s.IsCompressed() {
+		return false, nil
+	}
+	encoded, err := json.Marshal(s.Data)
+	if err != nil {
+		return false, err
+	}
+	if len(encoded) < threshold {
+		return false, nil
+	}
+
+	compressed, err := CompressStreamData(s.Data)
+	if err != nil {
+		return false, err
+	}
+	s.Data = map[string]interface{}{CompressedDataField: compressed}
+	s.Compressed = true
+	return true, nil
+}
+
+// IsCompressed reports whether Data holds a gzip payload. Messages read back
+// from Redis lose the Compressed flag, so a lone CompressedDataField also counts
+func (s *StreamMessage) IsCompressed() bool {
+	if s.Compressed {
+		return true
+	}
+	_, ok := s.Data[CompressedDataField]
+	return ok && len(s.Data) == 1
+}
+
+// Decompress restores the original Data of a compressed message in place
+func (s *StreamMessage) Decompress() error {
+	if !s.IsCompressed() {
+		return nil
+	}
+	data, err := s.decompressedData()
+	if err != nil {
+		return err
+	}
+	s.Data = data
+	s.Compressed = false
+	return nil
+}
+
+// decompressedData decodes the gzip payload, which Redis clients may return
+// as either []byte or string
+func (s *StreamMessage) decompressedData() (map[string]interface{}, error) {
+	switch payload := s.Data[CompressedDataField].(type) {
+	case []byte:
+		return DecompressStreamData(payload)
+	case string:
+		return DecompressStreamData([]byte(payload))
+	default:
+		return nil, fmt.Errorf("invalid compressed payload type %T", payload)
+	}
+}
@@ -0,0 +1,485 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shamaton/msgpack/v2"
+)
+
+func TestDedupWriter(t *testing.T) {
+	var written []*StreamMessage
+	writer := NewDedupWriter(func(msg *StreamMessage) error {
+		written = append(written, msg)
+		return nil
+	}, time.Minute)
+
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	writer.now = func() time.Time { return clock }
+
+	heatmap := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    1234567890,
+		CurrentPrice: 45000.0,
+		Levels:       []LiquidationLevel{{Price: 44000.0, TotalVolume: 100000.0}},
+	}
+	send := func(h HeatmapData) bool {
+		msg, err := ToStreamMessage(GetHeatmapStreamName(h.Symbol), h)
+		if err != nil {
+			t.Fatalf("ToStreamMessage() error = %v", err)
+		}
+		sent, err := writer.Write(msg)
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		return sent
+	}
+
+	if !send(heatmap) {
+		t.Error("first heatmap should be written")
+	}
+
+	clock = clock.Add(time.Second)
+	if send(heatmap) {
+		t.Error("identical heatmap should be suppressed")
+	}
+
+	changed := heatmap
+	changed.CurrentPrice = 45100.0
+	if !send(changed) {
+		t.Error("changed heatmap should be written")
+	}
+
+	clock = clock.Add(2 * time.Minute)
+	if !send(changed) {
+		t.Error("unchanged heatmap should be refreshed after the minimum interval")
+	}
+
+	if len(written) != 3 {
+		t.Errorf("written %d messages, expected 3", len(written))
+	}
+}
+
+func TestFromStreamMessageRoundTrip(t *testing.T) {
+	timestamp := int64(1700000000123)
+
+	t.Run("liquidation event", func(t *testing.T) {
+		event := LiquidationEvent{
+			Exchange:       ExchangeBinance,
+			Symbol:         SymbolBTCUSDT,
+			Timestamp:      timestamp,
+			Side:           SideSell,
+			Price:          45000.5,
+			Quantity:       1.5,
+			Value:          67500.75,
+			OrderType:      OrderTypeLiquidation,
+			OrderStatus:    "FILLED",
+			OrderTradeTime: timestamp + 1,
+		}
+		var decoded LiquidationEvent
+		roundTrip(t, event, &decoded)
+		if !reflect.DeepEqual(decoded, event) {
+			t.Errorf("round trip = %+v, expected %+v", decoded, event)
+		}
+	})
+
+	t.Run("market snapshot", func(t *testing.T) {
+		snapshot := MarketSnapshot{
+			Exchange:        ExchangeOKX,
+			Symbol:          SymbolETHUSDT,
+			Timestamp:       timestamp,
+			MarkPrice:       2500.25,
+			IndexPrice:      2500.1,
+			FundingRate:     0.0001,
+			OpenInterest:    120000.0,
+			OpenInterestUSD: 300030000.0,
+			NextFundingTime: timestamp + 3600000,
+		}
+		var decoded MarketSnapshot
+		roundTrip(t, snapshot, &decoded)
+		if !reflect.DeepEqual(decoded, snapshot) {
+			t.Errorf("round trip = %+v, expected %+v", decoded, snapshot)
+		}
+	})
+
+	t.Run("heatmap data", func(t *testing.T) {
+		heatmap := HeatmapData{
+			Symbol:       SymbolBTCUSDT,
+			Exchange:     ExchangeBybit,
+			Timestamp:    timestamp,
+			Interval:     Interval1m,
+			CurrentPrice: 45000.0,
+			Levels: []LiquidationLevel{
+				{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0, Intensity: 100.0, Timestamp: timestamp},
+			},
+			Clusters: []LiquidationCluster{
+				{Symbol: SymbolBTCUSDT, PriceRangeStart: 44000.0, PriceRangeEnd: 44000.0, TotalVolume: 100000.0},
+			},
+			Summary: HeatmapSummary{
+				TotalLongLiquidations: 100000.0,
+				SignificantLevels:     1,
+				CriticalZones:         []CriticalZone{{PriceStart: 44000.0, PriceEnd: 44000.0, Type: "long"}},
+			},
+		}
+		var decoded HeatmapData
+		roundTrip(t, heatmap, &decoded)
+		if !reflect.DeepEqual(decoded, heatmap) {
+			t.Errorf("round trip = %+v, expected %+v", decoded, heatmap)
+		}
+	})
+}
+
+func TestFromStreamMessageErrors(t *testing.T) {
+	msg := &StreamMessage{Data: map[string]interface{}{"price": "not-a-number"}}
+	var event LiquidationEvent
+	if err := FromStreamMessage(msg, &event); err == nil {
+		t.Error("FromStreamMessage() should reject a malformed numeric field")
+	}
+	if err := FromStreamMessage(msg, event); err == nil {
+		t.Error("FromStreamMessage() should reject a non-pointer target")
+	}
+	if err := FromStreamMessage(nil, &event); err == nil {
+		t.Error("FromStreamMessage() should reject a nil message")
+	}
+}
+
+func roundTrip(t *testing.T, v interface{}, target interface{}) {
+	t.Helper()
+	msg, err := ToStreamMessage("round-trip", v)
+	if err != nil {
+		t.Fatalf("ToStreamMessage() error = %v", err)
+	}
+	if err := FromStreamMessage(msg, target); err != nil {
+		t.Fatalf("FromStreamMessage() error = %v", err)
+	}
+}
+
+func TestToStreamMessageMsgpackRoundTrip(t *testing.T) {
+	timestamp := int64(1700000000123)
+	tests := []struct {
+		name   string
+		value  interface{}
+		target func() interface{}
+	}{
+		{
+			name: "liquidation event",
+			value: &LiquidationEvent{
+				Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: timestamp, Side: SideSell,
+				Price: 45000.5, Quantity: 1.5, Value: 67500.75, OrderType: OrderTypeLiquidation,
+			},
+			target: func() interface{} { return &LiquidationEvent{} },
+		},
+		{
+			name: "order book",
+			value: &OrderBookSnapshot{
+				Exchange: ExchangeBybit, Symbol: SymbolETHUSDT, Timestamp: timestamp, LastUpdateID: 1 << 40,
+				Bids: []PriceLevel{{Price: 2499.5, Quantity: 10.25, Count: 3}},
+				Asks: []PriceLevel{{Price: 2500.5, Quantity: 4.0}, {Price: 2501.0, Quantity: 0.001}},
+			},
+			target: func() interface{} { return &OrderBookSnapshot{} },
+		},
+		{
+			name: "heatmap data",
+			value: &HeatmapData{
+				Symbol: SymbolBTCUSDT, Timestamp: timestamp, Interval: Interval1m, CurrentPrice: 45000.0,
+				Levels: []LiquidationLevel{
+					{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0, Intensity: 100.0, Timestamp: timestamp},
+					{Price: 46000.5, ShortLiquidations: 25000.25, TotalVolume: 25000.25, Intensity: 25.00025, Timestamp: timestamp},
+				},
+				Clusters: []LiquidationCluster{{Symbol: SymbolBTCUSDT, PriceRangeStart: 44000.0, PriceRangeEnd: 44000.0}},
+				Summary: HeatmapSummary{
+					TotalLongLiquidations: 100000.0,
+					SignificantLevels:     1,
+					CriticalZones:         []CriticalZone{{PriceStart: 44000.0, PriceEnd: 44000.0, Type: "long"}},
+				},
+			},
+			target: func() interface{} { return &HeatmapData{} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packed, err := ToStreamMessageMsgpack("round-trip", tt.value)
+			if err != nil {
+				t.Fatalf("ToStreamMessageMsgpack() error = %v", err)
+			}
+			for key, value := range packed.Data {
+				if _, isString := value.(string); isString && (key == "levels" || key == "bids" || key == "summary") {
+					t.Errorf("nested field %s encoded as JSON string, expected msgpack bytes", key)
+				}
+			}
+
+			fromMsgpack, fromJSON := tt.target(), tt.target()
+			if err := FromStreamMessage(packed, fromMsgpack); err != nil {
+				t.Fatalf("FromStreamMessage() msgpack error = %v", err)
+			}
+			roundTrip(t, tt.value, fromJSON)
+
+			if !reflect.DeepEqual(fromMsgpack, fromJSON) {
+				t.Errorf("msgpack round trip = %+v, JSON round trip = %+v", fromMsgpack, fromJSON)
+			}
+			if !reflect.DeepEqual(fromMsgpack, tt.value) {
+				t.Errorf("msgpack round trip = %+v, expected %+v", fromMsgpack, tt.value)
+			}
+		})
+	}
+}
+
+func TestToStreamMessageMsgpackTags(t *testing.T) {
+	heatmap := &HeatmapData{
+		Symbol: SymbolBTCUSDT,
+		Levels: []LiquidationLevel{{Price: 44000.0, LongLiquidations: 100000.0, TotalVolume: 100000.0}},
+	}
+	packed, err := ToStreamMessageMsgpack("tags", heatmap)
+	if err != nil {
+		t.Fatalf("ToStreamMessageMsgpack() error = %v", err)
+	}
+	levels, ok := packed.Data["levels"].([]byte)
+	if !ok {
+		t.Fatalf("levels = %T, expected msgpack bytes", packed.Data["levels"])
+	}
+
+	var decoded []map[string]interface{}
+	if err := msgpack.Unmarshal(levels, &decoded); err != nil {
+		t.Fatalf("msgpack.Unmarshal() error = %v", err)
+	}
+	if len(decoded) != 1 || decoded[0]["long_liquidations"] != 100000.0 {
+		t.Errorf("decoded levels = %v, expected long_liquidations keyed by its msgpack tag", decoded)
+	}
+	if _, ok := decoded[0]["weighted_intensity"]; ok {
+		t.Error("zero weighted_intensity should be omitted by its omitempty msgpack tag")
+	}
+}
+
+func TestXAddArgs(t *testing.T) {
+	event := LiquidationEvent{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: 1234567890,
+		Side:      SideSell,
+		Price:     45000.0,
+		Quantity:  1.5,
+	}
+	streamName := GetLiquidationStreamName(event.Exchange, event.Symbol)
+	msg, err := ToStreamMessage(streamName, event)
+	if err != nil {
+		t.Fatalf("ToStreamMessage() error = %v", err)
+	}
+
+	stream, values, maxLen := msg.XAddArgs(10000)
+	if stream != streamName {
+		t.Errorf("stream = %v, expected %v", stream, streamName)
+	}
+	if !reflect.DeepEqual(values, msg.Data) {
+		t.Errorf("values = %v, expected %v", values, msg.Data)
+	}
+	if maxLen != 10000 {
+		t.Errorf("approxMaxLen = %v, expected 10000", maxLen)
+	}
+
+	values["extra"] = "field"
+	if _, ok := msg.Data["extra"]; ok {
+		t.Error("mutating values should not change the message Data")
+	}
+}
+
+func TestDecodeLiquidationStream(t *testing.T) {
+	input := strings.Join([]string{
+		`{"exchange":"binance","symbol":"BTCUSDT","timestamp":1000,"side":"SELL","price":45000,"quantity":1.5,"order_type":"liquidation"}`,
+		`{"exchange":"binance","symbol":"BTCUSDT","timestamp":`, // malformed
+		``,
+		`{"exchange":"okx","symbol":"ETHUSDT","timestamp":2000,"side":"BUY","price":2500,"quantity":0}`, // invalid quantity
+		`{"exchange":"bybit","symbol":"SOLUSDT","timestamp":3000,"side":"long","price":100,"quantity":20}`,
+	}, "\n")
+
+	events, errs := DecodeLiquidationStream(context.Background(), strings.NewReader(input))
+
+	// Ranging over events first must not deadlock on queued errors
+	var decoded []LiquidationEvent
+	for event := range events {
+		decoded = append(decoded, event)
+	}
+	var failures []error
+	for err := range errs {
+		failures = append(failures, err)
+	}
+
+	if len(decoded) != 2 || decoded[0].Symbol != SymbolBTCUSDT || decoded[1].Symbol != SymbolSOLUSDT {
+		t.Errorf("DecodeLiquidationStream() events = %+v, expected BTCUSDT and SOLUSDT", decoded)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("DecodeLiquidationStream() errors = %v, expected 2", failures)
+	}
+	if !strings.HasPrefix(failures[0].Error(), "line 2:") || !strings.HasPrefix(failures[1].Error(), "line 4:") {
+		t.Errorf("DecodeLiquidationStream() errors = %v, expected lines 2 and 4", failures)
+	}
+}
+
+func TestDecodeLiquidationStreamCancel(t *testing.T) {
+	line := `{"exchange":"binance","symbol":"BTCUSDT","timestamp":1000,"side":"SELL","price":45000,"quantity":1.5}`
+	input := strings.Repeat(line+"\nnot json\n", 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := DecodeLiquidationStream(ctx, strings.NewReader(input))
+	<-events
+	cancel()
+
+	// Both channels close once the decoder sees the cancellation, with at
+	// most the event already being delivered still received
+	received := make(chan int)
+	go func() {
+		count := 0
+		for range events {
+			count++
+		}
+		for range errs {
+		}
+		received <- count
+	}()
+	select {
+	case count := <-received:
+		if count > 1 {
+			t.Errorf("DecodeLiquidationStream() sent %d events after cancel, expected at most 1", count)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DecodeLiquidationStream() did not stop after cancel")
+	}
+}
+
+func TestCompressStreamData(t *testing.T) {
+	heatmap := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    1700000000000,
+		Interval:     Interval1m,
+		CurrentPrice: 45000.0,
+	}
+	for i := 0; i < 200; i++ {
+		heatmap.Levels = append(heatmap.Levels, LiquidationLevel{
+			Price:            40000.0 + float64(i)*50,
+			LongLiquidations: float64(i) * 1000,
+			TotalVolume:      float64(i) * 1000,
+			Intensity:        float64(i) / 2,
+			Timestamp:        1700000000000,
+		})
+	}
+
+	msg, err := ToStreamMessage(GetHeatmapStreamName(heatmap.Symbol), heatmap)
+	if err != nil {
+		t.Fatalf("ToStreamMessage() error = %v", err)
+	}
+	encoded, _ := json.Marshal(msg.Data)
+
+	compressed, err := CompressStreamData(msg.Data)
+	if err != nil {
+		t.Fatalf("CompressStreamData() error = %v", err)
+	}
+	if len(compressed) >= len(encoded) {
+		t.Errorf("CompressStreamData() = %d bytes, expected fewer than %d", len(compressed), len(encoded))
+	}
+
+	data, err := DecompressStreamData(compressed)
+	if err != nil {
+		t.Fatalf("DecompressStreamData() error = %v", err)
+	}
+	if data["levels"] != msg.Data["levels"] || data["symbol"] != "BTCUSDT" || data["timestamp"] != float64(1700000000000) {
+		t.Errorf("DecompressStreamData() did not restore the data map")
+	}
+
+	if _, err := DecompressStreamData([]byte("not gzip")); err == nil {
+		t.Error("DecompressStreamData() with invalid payload expected error")
+	}
+}
+
+func TestStreamMessageCompress(t *testing.T) {
+	heatmap := HeatmapData{Symbol: SymbolBTCUSDT, Timestamp: 1700000000000, Interval: Interval1m, CurrentPrice: 45000.0}
+	for i := 0; i < 100; i++ {
+		heatmap.Levels = append(heatmap.Levels, LiquidationLevel{Price: 40000.0 + float64(i)*50, TotalVolume: 1000.0})
+	}
+	msg, err := ToStreamMessage(GetHeatmapStreamName(heatmap.Symbol), heatmap)
+	if err != nil {
+		t.Fatalf("ToStreamMessage() error = %v", err)
+	}
+
+	if compressed, err := msg.Compress(1 << 20); err != nil || compressed {
+		t.Fatalf("Compress() below threshold = %v, %v, expected false", compressed, err)
+	}
+	if compressed, err := msg.Compress(1024); err != nil || !compressed || !msg.Compressed {
+		t.Fatalf("Compress() above threshold = %v, %v, expected true", compressed, err)
+	}
+
+	// Simulate a read from Redis: the flag is lost and the payload is a string
+	read := &StreamMessage{
+		Stream: msg.Stream,
+		Data:   map[string]interface{}{CompressedDataField: string(msg.Data[CompressedDataField].([]byte))},
+	}
+	var decoded HeatmapData
+	if err := FromStreamMessage(read, &decoded); err != nil {
+		t.Fatalf("FromStreamMessage() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, heatmap) {
+		t.Errorf("FromStreamMessage() = %+v, expected %+v", decoded, heatmap)
+	}
+
+	if err := msg.Decompress(); err != nil || msg.Compressed || msg.Data["symbol"] != "BTCUSDT" {
+		t.Errorf("Decompress() = %v, data %v, expected restored data", err, msg.Data["symbol"])
+	}
+}
+
+func TestDecodeByStream(t *testing.T) {
+	event := &LiquidationEvent{
+		Exchange: ExchangeBinance, Symbol: SymbolBTCUSDT, Timestamp: 1700000000000,
+		Side: SideSell, Price: 45000.0, Quantity: 1.5, Value: 67500.0, OrderType: OrderTypeLiquidation,
+	}
+	market := &MarketSnapshot{
+		Exchange: ExchangeOKX, Symbol: SymbolETHUSDT, Timestamp: 1700000000000,
+		MarkPrice: 2500.0, FundingRate: 0.0001,
+	}
+	book := &OrderBookSnapshot{
+		Exchange: ExchangeBybit, Symbol: SymbolBNBUSDT, Timestamp: 1700000000000,
+		Bids: []PriceLevel{{Price: 300.0, Quantity: 10.0}}, Asks: []PriceLevel{{Price: 300.5, Quantity: 8.0}},
+	}
+	heatmap := &HeatmapData{
+		Symbol: SymbolBTCUSDT, Timestamp: 1700000000000, Interval: Interval1m, CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{{Price: 44000.0, TotalVolume: 100000.0}},
+	}
+
+	tests := []struct {
+		name   string
+		stream string
+		value  interface{}
+	}{
+		{name: "liquidation", stream: GetLiquidationStreamName(event.Exchange, event.Symbol), value: event},
+		{name: "market", stream: GetMarketStreamName(market.Exchange, market.Symbol), value: market},
+		{name: "orderbook", stream: GetOrderBookStreamName(book.Exchange, book.Symbol), value: book},
+		{name: "heatmap", stream: GetHeatmapStreamName(heatmap.Symbol), value: heatmap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := ToStreamMessage(tt.stream, tt.value)
+			if err != nil {
+				t.Fatalf("ToStreamMessage() error = %v", err)
+			}
+			decoded, err := msg.DecodeByStream()
+			if err != nil {
+				t.Fatalf("DecodeByStream() error = %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.value) {
+				t.Errorf("DecodeByStream() = %#v, expected %#v", decoded, tt.value)
+			}
+		})
+	}
+
+	unknown := &StreamMessage{Stream: "trades:binance:BTCUSDT"}
+	if _, err := unknown.DecodeByStream(); err == nil {
+		t.Error("DecodeByStream() with unknown data type expected error")
+	}
+	malformed := &StreamMessage{Stream: "liquidations"}
+	if _, err := malformed.DecodeByStream(); err == nil {
+		t.Error("DecodeByStream() with malformed stream name expected error")
+	}
+}
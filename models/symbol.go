@@ -0,0 +1,196 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ===========================================
+// SYMBOL HELPERS
+// ===========================================
+
+// quoteAssets lists recognized quote assets, longest first so "BTCUSDT"
+// matches "USDT" before "USD"
+var quoteAssets = []string{"USDT", "USDC", "BUSD", "USD", "BTC", "ETH"}
+
+// symbolDelimiters separate base and quote in delimited symbol formats
+const symbolDelimiters = "-_/:"
+
+// splitSymbol returns the base and quote assets of a symbol, or the whole
+// symbol as base and an empty quote when it cannot be split
+func splitSymbol(s Symbol) (string, string) {
+	str := string(s)
+	if i := strings.IndexAny(str, symbolDelimiters); i > 0 {
+		rest := str[i+1:]
+		if j := strings.IndexAny(rest, symbolDelimiters); j >= 0 {
+			rest = rest[:j]
+		}
+		if rest != "" {
+			return str[:i], rest
+		}
+	}
+
+	for _, quote := range quoteAssets {
+		if len(str) > len(quote) && strings.HasSuffix(str, quote) {
+			return strings.TrimSuffix(str, quote), quote
+		}
+	}
+	return str, ""
+}
+
+// Base returns the base asset, e.g. "BTC" for BTCUSDT
+func (s Symbol) Base() string {
+	base, _ := splitSymbol(s)
+	return base
+}
+
+// Quote returns the quote asset, e.g. "USDT" for BTCUSDT, or "" when the
+// symbol cannot be split
+func (s Symbol) Quote() string {
+	_, quote := splitSymbol(s)
+	return quote
+}
+
+// NormalizeSymbol converts an exchange-native symbol such as OKX "BTC-USDT-SWAP"
+// or Deribit "BTC_USDT-PERPETUAL" to the canonical concatenated form. Symbols
+// that cannot be recognized pass through unchanged so no data is dropped
+func NormalizeSymbol(exchange Exchange, raw string) (Symbol, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("symbol is required")
+	}
+
+	s := raw
+	switch exchange {
+	case ExchangeBitfinex:
+		s = strings.TrimPrefix(s, "t")
+		s = strings.ReplaceAll(s, "F0", "")
+	case ExchangeKraken:
+		for _, prefix := range []string{"PF_", "PI_", "FI_"} {
+			s = strings.TrimPrefix(strings.ToUpper(s), prefix)
+		}
+	}
+	s = strings.ToUpper(s)
+	for _, suffix := range []string{"-SWAP", "-PERPETUAL", "-PERP"} {
+		s = strings.TrimSuffix(s, suffix)
+	}
+
+	base, quote := splitSymbol(Symbol(s))
+	if quote == "" && exchange == ExchangeDeribit && !strings.ContainsAny(base, symbolDelimiters) {
+		// Deribit inverse perpetuals ("BTC-PERPETUAL") are USD margined
+		quote = "USD"
+	}
+	if quote == "" {
+		return Symbol(raw), nil
+	}
+
+	switch exchange {
+	case ExchangeKraken:
+		if base == "XBT" {
+			base = "BTC"
+		}
+	case ExchangeBitfinex:
+		if quote == "UST" {
+			quote = "USDT"
+		}
+	}
+
+	return Symbol(base + quote), nil
+}
+
+// ToExchangeFormat converts a canonical symbol to the exchange's native
+// perpetual contract naming for outbound API calls. Symbols that cannot be
+// split are returned unchanged
+func (s Symbol) ToExchangeFormat(exchange Exchange) string {
+	base, quote := splitSymbol(s)
+	if quote == "" {
+		return string(s)
+	}
+
+	switch exchange {
+	case ExchangeOKX:
+		return base + "-" + quote + "-SWAP"
+	case ExchangeDeribit:
+		if quote == "USD" {
+			return base + "-PERPETUAL"
+		}
+		return base + "_" + quote + "-PERPETUAL"
+	case ExchangeCoinbase:
+		return base + "-" + quote
+	case ExchangeKraken:
+		if base == "BTC" {
+			base = "XBT"
+		}
+		return "PF_" + base + quote
+	case ExchangeBitfinex:
+		if quote == "USDT" {
+			quote = "UST"
+		}
+		return "t" + base + "F0:" + quote + "F0"
+	default:
+		return base + quote
+	}
+}
+
+// symbolPrecision holds the price and quantity decimal places of a symbol,
+// and the exchange tick size its prices move in
+type symbolPrecision struct {
+	price    int
+	quantity int
+	tickSize float64
+}
+
+// symbolPrecisions lists decimal places and tick sizes for known symbols
+var symbolPrecisions = map[Symbol]symbolPrecision{
+	SymbolBTCUSDT: {price: 0, quantity: 3, tickSize: 0.1},
+	SymbolETHUSDT: {price: 2, quantity: 3, tickSize: 0.01},
+	SymbolBNBUSDT: {price: 2, quantity: 2, tickSize: 0.01},
+	SymbolSOLUSDT: {price: 3, quantity: 1, tickSize: 0.001},
+	SymbolXRPUSDT: {price: 4, quantity: 1, tickSize: 0.0001},
+}
+
+// defaultSymbolPrecision applies to symbols missing from symbolPrecisions; it
+// keeps enough decimals that rounding never discards meaningful digits
+var defaultSymbolPrecision = symbolPrecision{price: 8, quantity: 8, tickSize: 1e-8}
+
+// precision returns the decimal places for s, or defaultSymbolPrecision
+func (s Symbol) precision() symbolPrecision {
+	if p, ok := symbolPrecisions[s]; ok {
+		return p
+	}
+	return defaultSymbolPrecision
+}
+
+// PricePrecision returns the number of decimal places prices of s carry,
+// e.g. 0 for BTCUSDT and 4 for XRPUSDT
+func (s Symbol) PricePrecision() int {
+	return s.precision().price
+}
+
+// QuantityPrecision returns the number of decimal places quantities of s carry
+func (s Symbol) QuantityPrecision() int {
+	return s.precision().quantity
+}
+
+// TickSize returns the minimum price increment of s on the exchange, e.g. 0.1
+// for BTCUSDT. It can be finer than PricePrecision, which is for display
+func (s Symbol) TickSize() float64 {
+	return s.precision().tickSize
+}
+
+// RoundPrice rounds p to the symbol's PricePrecision, stripping float noise
+func RoundPrice(symbol Symbol, p float64) float64 {
+	return roundToDecimals(p, symbol.PricePrecision())
+}
+
+// RoundQuantity rounds q to the symbol's QuantityPrecision
+func RoundQuantity(symbol Symbol, q float64) float64 {
+	return roundToDecimals(q, symbol.QuantityPrecision())
+}
+
+// roundToDecimals rounds v half away from zero to the given decimal places
+func roundToDecimals(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSymbolBaseQuote(t *testing.T) {
+	tests := []struct {
+		symbol Symbol
+		base   string
+		quote  string
+	}{
+		{symbol: SymbolBTCUSDT, base: "BTC", quote: "USDT"},
+		{symbol: SymbolETHUSDT, base: "ETH", quote: "USDT"},
+		{symbol: SymbolBNBUSDT, base: "BNB", quote: "USDT"},
+		{symbol: SymbolSOLUSDT, base: "SOL", quote: "USDT"},
+		{symbol: SymbolXRPUSDT, base: "XRP", quote: "USDT"},
+		{symbol: "ETHBTC", base: "ETH", quote: "BTC"},
+		{symbol: "BTCUSD", base: "BTC", quote: "USD"},
+		{symbol: "BTC-USDT", base: "BTC", quote: "USDT"},
+		{symbol: "BTC-USD-SWAP", base: "BTC", quote: "USD"},
+		{symbol: "ETH_USDC", base: "ETH", quote: "USDC"},
+		{symbol: "NOTASYMBOL", base: "NOTASYMBOL", quote: ""},
+		{symbol: "USDT", base: "USDT", quote: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.symbol), func(t *testing.T) {
+			if result := tt.symbol.Base(); result != tt.base {
+				t.Errorf("Base() = %v, expected %v", result, tt.base)
+			}
+			if result := tt.symbol.Quote(); result != tt.quote {
+				t.Errorf("Quote() = %v, expected %v", result, tt.quote)
+			}
+		})
+	}
+}
+
+func TestNormalizeSymbol(t *testing.T) {
+	tests := []struct {
+		name     string
+		exchange Exchange
+		raw      string
+		expected Symbol
+		wantErr  bool
+	}{
+		{name: "binance", exchange: ExchangeBinance, raw: "BTCUSDT", expected: SymbolBTCUSDT},
+		{name: "bybit lowercase", exchange: ExchangeBybit, raw: "ethusdt", expected: SymbolETHUSDT},
+		{name: "okx spot", exchange: ExchangeOKX, raw: "BTC-USDT", expected: SymbolBTCUSDT},
+		{name: "okx swap", exchange: ExchangeOKX, raw: "SOL-USDT-SWAP", expected: SymbolSOLUSDT},
+		{name: "okx coin margined", exchange: ExchangeOKX, raw: "BTC-USD-SWAP", expected: "BTCUSD"},
+		{name: "deribit linear", exchange: ExchangeDeribit, raw: "XRP_USDT-PERPETUAL", expected: SymbolXRPUSDT},
+		{name: "deribit inverse", exchange: ExchangeDeribit, raw: "BTC-PERPETUAL", expected: "BTCUSD"},
+		{name: "coinbase", exchange: ExchangeCoinbase, raw: "ETH-USDT", expected: SymbolETHUSDT},
+		{name: "kraken", exchange: ExchangeKraken, raw: "PF_XBTUSD", expected: "BTCUSD"},
+		{name: "bitfinex", exchange: ExchangeBitfinex, raw: "tBTCF0:USTF0", expected: SymbolBTCUSDT},
+		{name: "unknown passes through", exchange: ExchangeOKX, raw: "WEIRD", expected: "WEIRD"},
+		{name: "empty", exchange: ExchangeBinance, raw: " ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NormalizeSymbol(tt.exchange, tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeSymbol() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("NormalizeSymbol(%v, %q) = %v, expected %v", tt.exchange, tt.raw, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSymbolToExchangeFormat(t *testing.T) {
+	tests := []struct {
+		exchange Exchange
+		symbol   Symbol
+		expected string
+	}{
+		{exchange: ExchangeBinance, symbol: SymbolBTCUSDT, expected: "BTCUSDT"},
+		{exchange: ExchangeBybit, symbol: SymbolETHUSDT, expected: "ETHUSDT"},
+		{exchange: ExchangeOKX, symbol: SymbolBTCUSDT, expected: "BTC-USDT-SWAP"},
+		{exchange: ExchangeDeribit, symbol: SymbolXRPUSDT, expected: "XRP_USDT-PERPETUAL"},
+		{exchange: ExchangeDeribit, symbol: "BTCUSD", expected: "BTC-PERPETUAL"},
+		{exchange: ExchangeCoinbase, symbol: SymbolSOLUSDT, expected: "SOL-USDT"},
+		{exchange: ExchangeKraken, symbol: "BTCUSD", expected: "PF_XBTUSD"},
+		{exchange: ExchangeBitfinex, symbol: SymbolBTCUSDT, expected: "tBTCF0:USTF0"},
+		{exchange: ExchangeOKX, symbol: "WEIRD", expected: "WEIRD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.exchange)+"/"+string(tt.symbol), func(t *testing.T) {
+			result := tt.symbol.ToExchangeFormat(tt.exchange)
+			if result != tt.expected {
+				t.Fatalf("ToExchangeFormat() = %v, expected %v", result, tt.expected)
+			}
+
+			// Round-trip back to canonical
+			back, err := NormalizeSymbol(tt.exchange, result)
+			if err != nil {
+				t.Fatalf("NormalizeSymbol() error = %v", err)
+			}
+			if back != tt.symbol {
+				t.Errorf("round trip = %v, expected %v", back, tt.symbol)
+			}
+		})
+	}
+}
+
+func TestSymbolPrecision(t *testing.T) {
+	tests := []struct {
+		name             string
+		symbol           Symbol
+		price            float64
+		quantity         float64
+		expectedPrice    float64
+		expectedQuantity float64
+	}{
+		{name: "btc integer dollars", symbol: SymbolBTCUSDT, price: 45123.4567, quantity: 1.23456, expectedPrice: 45123, expectedQuantity: 1.235},
+		{name: "btc rounds up", symbol: SymbolBTCUSDT, price: 45123.5, quantity: 0.0004, expectedPrice: 45124, expectedQuantity: 0},
+		{name: "xrp four decimals", symbol: SymbolXRPUSDT, price: 0.123456, quantity: 1500.26, expectedPrice: 0.1235, expectedQuantity: 1500.3},
+		{name: "float noise", symbol: SymbolETHUSDT, price: 0.1 + 0.2 + 2500, quantity: 0.1 + 0.2, expectedPrice: 2500.3, expectedQuantity: 0.3},
+		{name: "unknown symbol", symbol: "PEPEUSDT", price: 0.0000012345, quantity: 1.5, expectedPrice: 0.00000123, expectedQuantity: 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := RoundPrice(tt.symbol, tt.price); result != tt.expectedPrice {
+				t.Errorf("RoundPrice() = %v, expected %v", result, tt.expectedPrice)
+			}
+			if result := RoundQuantity(tt.symbol, tt.quantity); result != tt.expectedQuantity {
+				t.Errorf("RoundQuantity() = %v, expected %v", result, tt.expectedQuantity)
+			}
+		})
+	}
+
+	if p := SymbolBTCUSDT.PricePrecision(); p != 0 {
+		t.Errorf("PricePrecision() = %v, expected 0", p)
+	}
+	if p := SymbolXRPUSDT.PricePrecision(); p != 4 {
+		t.Errorf("PricePrecision() = %v, expected 4", p)
+	}
+	if p := Symbol("PEPEUSDT").QuantityPrecision(); p != 8 {
+		t.Errorf("QuantityPrecision() = %v, expected 8", p)
+	}
+	if tick := SymbolBTCUSDT.TickSize(); tick != 0.1 {
+		t.Errorf("TickSize() = %v, expected 0.1", tick)
+	}
+	if tick := Symbol("PEPEUSDT").TickSize(); tick != 1e-8 {
+		t.Errorf("TickSize() of unknown symbol = %v, expected 1e-8", tick)
+	}
+}
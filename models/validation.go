@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// validationCheck pairs a failure condition with the error it reports
+type validationCheck struct {
+	failed  bool
+	message string
+}
+
+// collectErrors returns an error for every failed check, preserving order
+func collectErrors(checks ...validationCheck) []error {
+	var errs []error
+	for _, check := range checks {
+		if check.failed {
+			errs = append(errs, errors.New(check.message))
+		}
+	}
+	return errs
+}
+
+// firstError returns the first error of errs, or nil when there are none
+func firstError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
+
+// ===========================================
+// VALIDATION LEVELS
+// ===========================================
+
+// ValidationLevel selects how strictly events are validated
+type ValidationLevel int
+
+const (
+	// ValidationLenient runs only the required-field checks of Validate
+	ValidationLenient ValidationLevel = iota
+	// ValidationStandard adds enum membership checks for Side and OrderType
+	// and rejects a negative Value
+	ValidationStandard
+	// ValidationStrict adds supported exchange and symbol checks, rejects
+	// timestamps more than MaxClockSkew in the future and rejects a Price that
+	// is not a multiple of the symbol's TickSize
+	ValidationStrict
+)
+
+// MaxClockSkew is how far in the future a strictly validated timestamp may be
+var MaxClockSkew = 5 * time.Second
+
+// MaxFundingRate bounds the absolute per-interval funding rate accepted by
+// MarketSnapshot.Validate. Raise it for markets with unusually wide funding
+var MaxFundingRate = 0.05
+
+// ValidateAt validates the event at the given strictness level. Each level
+// runs every check of the levels below it
+func (l *LiquidationEvent) ValidateAt(level ValidationLevel) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+	if level < ValidationStandard {
+		return nil
+	}
+
+	if !l.Side.IsValid() {
+		return fmt.Errorf("invalid side: %q", l.Side)
+	}
+	if !l.OrderType.IsValid() {
+		return fmt.Errorf("invalid order type: %q", l.OrderType)
+	}
+	if l.Value < 0 {
+		return fmt.Errorf("invalid value")
+	}
+	if level < ValidationStrict {
+		return nil
+	}
+
+	if !l.Exchange.IsValid() {
+		return fmt.Errorf("unsupported exchange: %q", l.Exchange)
+	}
+	if !l.Symbol.IsValid() {
+		return fmt.Errorf("unsupported symbol: %q", l.Symbol)
+	}
+	if l.Timestamp > time.Now().Add(MaxClockSkew).UnixMilli() {
+		return fmt.Errorf("timestamp too far in the future")
+	}
+	tick := l.Symbol.TickSize()
+	if ticks := l.Price / tick; math.Abs(ticks-math.Round(ticks)) > 1e-6 {
+		return fmt.Errorf("price %v is not a multiple of tick %v", l.Price, tick)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateAt(t *testing.T) {
+	valid := LiquidationEvent{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: time.Now().UnixMilli(),
+		Side:      SideSell,
+		Price:     45000.0,
+		Quantity:  1.5,
+		OrderType: OrderTypeLiquidation,
+	}
+	with := func(modify func(*LiquidationEvent)) LiquidationEvent {
+		e := valid
+		modify(&e)
+		return e
+	}
+
+	tests := []struct {
+		name  string
+		event LiquidationEvent
+		// wantErr per level: lenient, standard, strict
+		wantErr [3]bool
+	}{
+		{
+			name:    "valid event",
+			event:   valid,
+			wantErr: [3]bool{false, false, false},
+		},
+		{
+			name:    "missing price fails every level",
+			event:   with(func(e *LiquidationEvent) { e.Price = 0 }),
+			wantErr: [3]bool{true, true, true},
+		},
+		{
+			name:    "lowercase wire side",
+			event:   with(func(e *LiquidationEvent) { e.Side = "sell" }),
+			wantErr: [3]bool{false, true, true},
+		},
+		{
+			name:    "unknown order type",
+			event:   with(func(e *LiquidationEvent) { e.OrderType = "market" }),
+			wantErr: [3]bool{false, true, true},
+		},
+		{
+			name:    "unsupported symbol",
+			event:   with(func(e *LiquidationEvent) { e.Symbol = "DOGEUSDT" }),
+			wantErr: [3]bool{false, false, true},
+		},
+		{
+			name:    "unknown exchange",
+			event:   with(func(e *LiquidationEvent) { e.Exchange = "foobar" }),
+			wantErr: [3]bool{false, false, true},
+		},
+		{
+			name:    "timestamp in the future",
+			event:   with(func(e *LiquidationEvent) { e.Timestamp = time.Now().Add(time.Hour).UnixMilli() }),
+			wantErr: [3]bool{false, false, true},
+		},
+		{
+			name:    "price on a sub-dollar tick",
+			event:   with(func(e *LiquidationEvent) { e.Price = 45000.1 }),
+			wantErr: [3]bool{false, false, false},
+		},
+		{
+			name:    "price off the tick",
+			event:   with(func(e *LiquidationEvent) { e.Price = 45000.05 }),
+			wantErr: [3]bool{false, false, true},
+		},
+		{
+			name:    "price on the tick with float noise",
+			event:   with(func(e *LiquidationEvent) { e.Symbol = SymbolETHUSDT; e.Price = 0.1 + 0.2 + 2500 }),
+			wantErr: [3]bool{false, false, false},
+		},
+	}
+
+	levels := []ValidationLevel{ValidationLenient, ValidationStandard, ValidationStrict}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, level := range levels {
+				err := tt.event.ValidateAt(level)
+				if (err != nil) != tt.wantErr[i] {
+					t.Errorf("ValidateAt(%d) error = %v, wantErr %v", level, err, tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMaxFundingRate(t *testing.T) {
+	defer func(prev float64) { MaxFundingRate = prev }(MaxFundingRate)
+
+	market := MarketSnapshot{
+		Exchange:    ExchangeBinance,
+		Symbol:      SymbolBTCUSDT,
+		Timestamp:   1700000000000,
+		MarkPrice:   45000.0,
+		FundingRate: 0.08,
+	}
+	if err := market.Validate(); err == nil || err.Error() != "funding rate out of range" {
+		t.Errorf("MarketSnapshot.Validate() = %v, expected funding rate out of range", err)
+	}
+
+	MaxFundingRate = 0.1
+	if err := market.Validate(); err != nil {
+		t.Errorf("MarketSnapshot.Validate() with relaxed bound = %v, expected nil", err)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	event := LiquidationEvent{
+		Exchange: ExchangeBinance,
+		Price:    -1.0,
+		Quantity: 1.5,
+	}
+
+	errs := event.ValidateAll()
+	expected := []string{"symbol is required", "invalid timestamp", "invalid price"}
+	if len(errs) != len(expected) {
+		t.Fatalf("LiquidationEvent.ValidateAll() returned %d errors, expected %d: %v", len(errs), len(expected), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expected[i] {
+			t.Errorf("LiquidationEvent.ValidateAll()[%d] = %q, expected %q", i, err, expected[i])
+		}
+	}
+	if err := event.Validate(); err == nil || err.Error() != expected[0] {
+		t.Errorf("LiquidationEvent.Validate() = %v, expected %q", err, expected[0])
+	}
+
+	market := MarketSnapshot{Symbol: SymbolBTCUSDT}
+	if errs := market.ValidateAll(); len(errs) != 3 {
+		t.Errorf("MarketSnapshot.ValidateAll() returned %d errors, expected 3: %v", len(errs), errs)
+	}
+
+	heatmap := HeatmapData{}
+	if errs := heatmap.ValidateAll(); len(errs) != 4 {
+		t.Errorf("HeatmapData.ValidateAll() returned %d errors, expected 4: %v", len(errs), errs)
+	}
+
+	windowed := HeatmapData{
+		Symbol:       SymbolBTCUSDT,
+		Timestamp:    1700000060000,
+		Interval:     Interval1m,
+		CurrentPrice: 45000.0,
+		Levels: []LiquidationLevel{
+			{Price: 44000.0, Timestamp: 1700000030000},
+			{Price: 0, Timestamp: 1700000090000},
+		},
+	}
+	levelErrs := []string{
+		"invalid level 1: price 0",
+		"invalid level 1: timestamp 1700000090000 outside interval window",
+	}
+	errs = windowed.ValidateAll()
+	if len(errs) != len(levelErrs) {
+		t.Fatalf("HeatmapData.ValidateAll() returned %d errors, expected %d: %v", len(errs), len(levelErrs), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != levelErrs[i] {
+			t.Errorf("HeatmapData.ValidateAll()[%d] = %q, expected %q", i, err, levelErrs[i])
+		}
+	}
+
+	valid := LiquidationEvent{
+		Exchange:  ExchangeBinance,
+		Symbol:    SymbolBTCUSDT,
+		Timestamp: time.Now().UnixMilli(),
+		Price:     45000.0,
+		Quantity:  1.5,
+	}
+	if errs := valid.ValidateAll(); len(errs) != 0 {
+		t.Errorf("LiquidationEvent.ValidateAll() on valid event = %v, expected none", errs)
+	}
+}
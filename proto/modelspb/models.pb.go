@@ -0,0 +1,1331 @@
+// Wire format for the gort-trade-model stream models.
+//
+// Field numbers are part of the wire contract: never renumber or reuse a
+// field. Retire removed fields with `reserved`. The Go types in
+// proto/modelspb are generated from this file by protoc-gen-go; regenerate
+// them with `go generate ./models` after every change.
+//
+// Exchanges and symbols are open sets (see RegisterExchange and
+// RegisterSymbol), so they travel as strings rather than enums.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: models.proto
+
+package modelspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_SIDE_LONG        Side = 1
+	Side_SIDE_SHORT       Side = 2
+	Side_SIDE_BUY         Side = 3 // Exchange wire side, liquidates a short
+	Side_SIDE_SELL        Side = 4 // Exchange wire side, liquidates a long
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_UNSPECIFIED",
+		1: "SIDE_LONG",
+		2: "SIDE_SHORT",
+		3: "SIDE_BUY",
+		4: "SIDE_SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_UNSPECIFIED": 0,
+		"SIDE_LONG":        1,
+		"SIDE_SHORT":       2,
+		"SIDE_BUY":         3,
+		"SIDE_SELL":        4,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[0].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[0]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_ORDER_TYPE_LIQUIDATION OrderType = 1
+	OrderType_ORDER_TYPE_ADL         OrderType = 2
+	OrderType_ORDER_TYPE_BANKRUPTCY  OrderType = 3
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "ORDER_TYPE_LIQUIDATION",
+		2: "ORDER_TYPE_ADL",
+		3: "ORDER_TYPE_BANKRUPTCY",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"ORDER_TYPE_LIQUIDATION": 1,
+		"ORDER_TYPE_ADL":         2,
+		"ORDER_TYPE_BANKRUPTCY":  3,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[1]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{1}
+}
+
+type ContractType int32
+
+const (
+	ContractType_CONTRACT_TYPE_UNSPECIFIED ContractType = 0 // Treated as linear
+	ContractType_CONTRACT_TYPE_LINEAR      ContractType = 1
+	ContractType_CONTRACT_TYPE_INVERSE     ContractType = 2
+)
+
+// Enum value maps for ContractType.
+var (
+	ContractType_name = map[int32]string{
+		0: "CONTRACT_TYPE_UNSPECIFIED",
+		1: "CONTRACT_TYPE_LINEAR",
+		2: "CONTRACT_TYPE_INVERSE",
+	}
+	ContractType_value = map[string]int32{
+		"CONTRACT_TYPE_UNSPECIFIED": 0,
+		"CONTRACT_TYPE_LINEAR":      1,
+		"CONTRACT_TYPE_INVERSE":     2,
+	}
+)
+
+func (x ContractType) Enum() *ContractType {
+	p := new(ContractType)
+	*p = x
+	return p
+}
+
+func (x ContractType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContractType) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[2].Descriptor()
+}
+
+func (ContractType) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[2]
+}
+
+func (x ContractType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContractType.Descriptor instead.
+func (ContractType) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{2}
+}
+
+type Interval int32
+
+const (
+	Interval_INTERVAL_UNSPECIFIED Interval = 0
+	Interval_INTERVAL_1S          Interval = 1
+	Interval_INTERVAL_1M          Interval = 2
+	Interval_INTERVAL_5M          Interval = 3
+	Interval_INTERVAL_15M         Interval = 4
+	Interval_INTERVAL_1H          Interval = 5
+	Interval_INTERVAL_4H          Interval = 6
+	Interval_INTERVAL_1D          Interval = 7
+	Interval_INTERVAL_1W          Interval = 8
+)
+
+// Enum value maps for Interval.
+var (
+	Interval_name = map[int32]string{
+		0: "INTERVAL_UNSPECIFIED",
+		1: "INTERVAL_1S",
+		2: "INTERVAL_1M",
+		3: "INTERVAL_5M",
+		4: "INTERVAL_15M",
+		5: "INTERVAL_1H",
+		6: "INTERVAL_4H",
+		7: "INTERVAL_1D",
+		8: "INTERVAL_1W",
+	}
+	Interval_value = map[string]int32{
+		"INTERVAL_UNSPECIFIED": 0,
+		"INTERVAL_1S":          1,
+		"INTERVAL_1M":          2,
+		"INTERVAL_5M":          3,
+		"INTERVAL_15M":         4,
+		"INTERVAL_1H":          5,
+		"INTERVAL_4H":          6,
+		"INTERVAL_1D":          7,
+		"INTERVAL_1W":          8,
+	}
+)
+
+func (x Interval) Enum() *Interval {
+	p := new(Interval)
+	*p = x
+	return p
+}
+
+func (x Interval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Interval) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[3].Descriptor()
+}
+
+func (Interval) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[3]
+}
+
+func (x Interval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Interval.Descriptor instead.
+func (Interval) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{3}
+}
+
+type LiquidationEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange       string       `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`    // Lowercase exchange name, e.g. "binance"
+	Symbol         string       `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`        // Uppercase trading pair, e.g. "BTCUSDT"
+	Timestamp      int64        `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix millis
+	Side           Side         `protobuf:"varint,4,opt,name=side,proto3,enum=gort.models.v1.Side" json:"side,omitempty"`
+	Price          float64      `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity       float64      `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Value          float64      `protobuf:"fixed64,7,opt,name=value,proto3" json:"value,omitempty"` // USD
+	OrderType      OrderType    `protobuf:"varint,8,opt,name=order_type,json=orderType,proto3,enum=gort.models.v1.OrderType" json:"order_type,omitempty"`
+	AvgPrice       float64      `protobuf:"fixed64,9,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	FilledQty      float64      `protobuf:"fixed64,10,opt,name=filled_qty,json=filledQty,proto3" json:"filled_qty,omitempty"`
+	OrderStatus    string       `protobuf:"bytes,11,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	OrderTradeTime int64        `protobuf:"varint,12,opt,name=order_trade_time,json=orderTradeTime,proto3" json:"order_trade_time,omitempty"`
+	ContractType   ContractType `protobuf:"varint,13,opt,name=contract_type,json=contractType,proto3,enum=gort.models.v1.ContractType" json:"contract_type,omitempty"`
+	ContractSize   float64      `protobuf:"fixed64,14,opt,name=contract_size,json=contractSize,proto3" json:"contract_size,omitempty"`
+}
+
+func (x *LiquidationEvent) Reset() {
+	*x = LiquidationEvent{}
+	mi := &file_models_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidationEvent) ProtoMessage() {}
+
+func (x *LiquidationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidationEvent.ProtoReflect.Descriptor instead.
+func (*LiquidationEvent) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LiquidationEvent) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *LiquidationEvent) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *LiquidationEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *LiquidationEvent) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *LiquidationEvent) GetAvgPrice() float64 {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetFilledQty() float64 {
+	if x != nil {
+		return x.FilledQty
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+func (x *LiquidationEvent) GetOrderTradeTime() int64 {
+	if x != nil {
+		return x.OrderTradeTime
+	}
+	return 0
+}
+
+func (x *LiquidationEvent) GetContractType() ContractType {
+	if x != nil {
+		return x.ContractType
+	}
+	return ContractType_CONTRACT_TYPE_UNSPECIFIED
+}
+
+func (x *LiquidationEvent) GetContractSize() float64 {
+	if x != nil {
+		return x.ContractSize
+	}
+	return 0
+}
+
+type MarketSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange        string  `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`    // Lowercase exchange name, e.g. "binance"
+	Symbol          string  `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`        // Uppercase trading pair, e.g. "BTCUSDT"
+	Timestamp       int64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix millis
+	MarkPrice       float64 `protobuf:"fixed64,4,opt,name=mark_price,json=markPrice,proto3" json:"mark_price,omitempty"`
+	IndexPrice      float64 `protobuf:"fixed64,5,opt,name=index_price,json=indexPrice,proto3" json:"index_price,omitempty"`
+	FundingRate     float64 `protobuf:"fixed64,6,opt,name=funding_rate,json=fundingRate,proto3" json:"funding_rate,omitempty"`
+	OpenInterest    float64 `protobuf:"fixed64,7,opt,name=open_interest,json=openInterest,proto3" json:"open_interest,omitempty"`            // Contracts
+	OpenInterestUsd float64 `protobuf:"fixed64,8,opt,name=open_interest_usd,json=openInterestUsd,proto3" json:"open_interest_usd,omitempty"` // USD
+	Volume_24H      float64 `protobuf:"fixed64,9,opt,name=volume_24h,json=volume24h,proto3" json:"volume_24h,omitempty"`
+	Turnover_24H    float64 `protobuf:"fixed64,10,opt,name=turnover_24h,json=turnover24h,proto3" json:"turnover_24h,omitempty"`
+	NextFundingTime int64   `protobuf:"varint,11,opt,name=next_funding_time,json=nextFundingTime,proto3" json:"next_funding_time,omitempty"`
+}
+
+func (x *MarketSnapshot) Reset() {
+	*x = MarketSnapshot{}
+	mi := &file_models_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketSnapshot) ProtoMessage() {}
+
+func (x *MarketSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketSnapshot.ProtoReflect.Descriptor instead.
+func (*MarketSnapshot) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MarketSnapshot) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *MarketSnapshot) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *MarketSnapshot) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetMarkPrice() float64 {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetIndexPrice() float64 {
+	if x != nil {
+		return x.IndexPrice
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetFundingRate() float64 {
+	if x != nil {
+		return x.FundingRate
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetOpenInterest() float64 {
+	if x != nil {
+		return x.OpenInterest
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetOpenInterestUsd() float64 {
+	if x != nil {
+		return x.OpenInterestUsd
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetVolume_24H() float64 {
+	if x != nil {
+		return x.Volume_24H
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetTurnover_24H() float64 {
+	if x != nil {
+		return x.Turnover_24H
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetNextFundingTime() int64 {
+	if x != nil {
+		return x.NextFundingTime
+	}
+	return 0
+}
+
+type LiquidationLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price             float64 `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	LongLiquidations  float64 `protobuf:"fixed64,2,opt,name=long_liquidations,json=longLiquidations,proto3" json:"long_liquidations,omitempty"`
+	ShortLiquidations float64 `protobuf:"fixed64,3,opt,name=short_liquidations,json=shortLiquidations,proto3" json:"short_liquidations,omitempty"`
+	TotalVolume       float64 `protobuf:"fixed64,4,opt,name=total_volume,json=totalVolume,proto3" json:"total_volume,omitempty"`
+	Intensity         float64 `protobuf:"fixed64,5,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	WeightedIntensity float64 `protobuf:"fixed64,6,opt,name=weighted_intensity,json=weightedIntensity,proto3" json:"weighted_intensity,omitempty"`
+	RelativeIntensity float64 `protobuf:"fixed64,7,opt,name=relative_intensity,json=relativeIntensity,proto3" json:"relative_intensity,omitempty"`
+	PriceLow          float64 `protobuf:"fixed64,8,opt,name=price_low,json=priceLow,proto3" json:"price_low,omitempty"`
+	PriceHigh         float64 `protobuf:"fixed64,9,opt,name=price_high,json=priceHigh,proto3" json:"price_high,omitempty"`
+	OldestTimestamp   int64   `protobuf:"varint,10,opt,name=oldest_timestamp,json=oldestTimestamp,proto3" json:"oldest_timestamp,omitempty"`
+	NewestTimestamp   int64   `protobuf:"varint,11,opt,name=newest_timestamp,json=newestTimestamp,proto3" json:"newest_timestamp,omitempty"`
+	Timestamp         int64   `protobuf:"varint,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OiFraction        float64 `protobuf:"fixed64,13,opt,name=oi_fraction,json=oiFraction,proto3" json:"oi_fraction,omitempty"`
+	DistancePct       float64 `protobuf:"fixed64,14,opt,name=distance_pct,json=distancePct,proto3" json:"distance_pct,omitempty"`
+}
+
+func (x *LiquidationLevel) Reset() {
+	*x = LiquidationLevel{}
+	mi := &file_models_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidationLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidationLevel) ProtoMessage() {}
+
+func (x *LiquidationLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidationLevel.ProtoReflect.Descriptor instead.
+func (*LiquidationLevel) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LiquidationLevel) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetLongLiquidations() float64 {
+	if x != nil {
+		return x.LongLiquidations
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetShortLiquidations() float64 {
+	if x != nil {
+		return x.ShortLiquidations
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetTotalVolume() float64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetIntensity() float64 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetWeightedIntensity() float64 {
+	if x != nil {
+		return x.WeightedIntensity
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetRelativeIntensity() float64 {
+	if x != nil {
+		return x.RelativeIntensity
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetPriceLow() float64 {
+	if x != nil {
+		return x.PriceLow
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetPriceHigh() float64 {
+	if x != nil {
+		return x.PriceHigh
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetOldestTimestamp() int64 {
+	if x != nil {
+		return x.OldestTimestamp
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetNewestTimestamp() int64 {
+	if x != nil {
+		return x.NewestTimestamp
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetOiFraction() float64 {
+	if x != nil {
+		return x.OiFraction
+	}
+	return 0
+}
+
+func (x *LiquidationLevel) GetDistancePct() float64 {
+	if x != nil {
+		return x.DistancePct
+	}
+	return 0
+}
+
+type LiquidationCluster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol          string              `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	PriceRangeStart float64             `protobuf:"fixed64,2,opt,name=price_range_start,json=priceRangeStart,proto3" json:"price_range_start,omitempty"`
+	PriceRangeEnd   float64             `protobuf:"fixed64,3,opt,name=price_range_end,json=priceRangeEnd,proto3" json:"price_range_end,omitempty"`
+	Levels          []*LiquidationLevel `protobuf:"bytes,4,rep,name=levels,proto3" json:"levels,omitempty"`
+	TotalVolume     float64             `protobuf:"fixed64,5,opt,name=total_volume,json=totalVolume,proto3" json:"total_volume,omitempty"`
+	PeakIntensity   float64             `protobuf:"fixed64,6,opt,name=peak_intensity,json=peakIntensity,proto3" json:"peak_intensity,omitempty"`
+	UpdatedAt       int64               `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *LiquidationCluster) Reset() {
+	*x = LiquidationCluster{}
+	mi := &file_models_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidationCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidationCluster) ProtoMessage() {}
+
+func (x *LiquidationCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidationCluster.ProtoReflect.Descriptor instead.
+func (*LiquidationCluster) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LiquidationCluster) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *LiquidationCluster) GetPriceRangeStart() float64 {
+	if x != nil {
+		return x.PriceRangeStart
+	}
+	return 0
+}
+
+func (x *LiquidationCluster) GetPriceRangeEnd() float64 {
+	if x != nil {
+		return x.PriceRangeEnd
+	}
+	return 0
+}
+
+func (x *LiquidationCluster) GetLevels() []*LiquidationLevel {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *LiquidationCluster) GetTotalVolume() float64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+func (x *LiquidationCluster) GetPeakIntensity() float64 {
+	if x != nil {
+		return x.PeakIntensity
+	}
+	return 0
+}
+
+func (x *LiquidationCluster) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type CriticalZone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PriceStart float64 `protobuf:"fixed64,1,opt,name=price_start,json=priceStart,proto3" json:"price_start,omitempty"`
+	PriceEnd   float64 `protobuf:"fixed64,2,opt,name=price_end,json=priceEnd,proto3" json:"price_end,omitempty"`
+	Type       string  `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // "long", "short", or "mixed"
+	Intensity  float64 `protobuf:"fixed64,4,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	Volume     float64 `protobuf:"fixed64,5,opt,name=volume,proto3" json:"volume,omitempty"`
+}
+
+func (x *CriticalZone) Reset() {
+	*x = CriticalZone{}
+	mi := &file_models_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CriticalZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CriticalZone) ProtoMessage() {}
+
+func (x *CriticalZone) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CriticalZone.ProtoReflect.Descriptor instead.
+func (*CriticalZone) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CriticalZone) GetPriceStart() float64 {
+	if x != nil {
+		return x.PriceStart
+	}
+	return 0
+}
+
+func (x *CriticalZone) GetPriceEnd() float64 {
+	if x != nil {
+		return x.PriceEnd
+	}
+	return 0
+}
+
+func (x *CriticalZone) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CriticalZone) GetIntensity() float64 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+func (x *CriticalZone) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type HeatmapSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalLongLiquidations  float64         `protobuf:"fixed64,1,opt,name=total_long_liquidations,json=totalLongLiquidations,proto3" json:"total_long_liquidations,omitempty"`
+	TotalShortLiquidations float64         `protobuf:"fixed64,2,opt,name=total_short_liquidations,json=totalShortLiquidations,proto3" json:"total_short_liquidations,omitempty"`
+	MaxLiquidationPrice    float64         `protobuf:"fixed64,3,opt,name=max_liquidation_price,json=maxLiquidationPrice,proto3" json:"max_liquidation_price,omitempty"`
+	MaxLiquidationVolume   float64         `protobuf:"fixed64,4,opt,name=max_liquidation_volume,json=maxLiquidationVolume,proto3" json:"max_liquidation_volume,omitempty"`
+	WeightedAvgLongPrice   float64         `protobuf:"fixed64,5,opt,name=weighted_avg_long_price,json=weightedAvgLongPrice,proto3" json:"weighted_avg_long_price,omitempty"`
+	WeightedAvgShortPrice  float64         `protobuf:"fixed64,6,opt,name=weighted_avg_short_price,json=weightedAvgShortPrice,proto3" json:"weighted_avg_short_price,omitempty"`
+	SignificantLevels      int64           `protobuf:"varint,7,opt,name=significant_levels,json=significantLevels,proto3" json:"significant_levels,omitempty"`
+	CriticalZones          []*CriticalZone `protobuf:"bytes,8,rep,name=critical_zones,json=criticalZones,proto3" json:"critical_zones,omitempty"`
+}
+
+func (x *HeatmapSummary) Reset() {
+	*x = HeatmapSummary{}
+	mi := &file_models_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapSummary) ProtoMessage() {}
+
+func (x *HeatmapSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapSummary.ProtoReflect.Descriptor instead.
+func (*HeatmapSummary) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HeatmapSummary) GetTotalLongLiquidations() float64 {
+	if x != nil {
+		return x.TotalLongLiquidations
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetTotalShortLiquidations() float64 {
+	if x != nil {
+		return x.TotalShortLiquidations
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetMaxLiquidationPrice() float64 {
+	if x != nil {
+		return x.MaxLiquidationPrice
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetMaxLiquidationVolume() float64 {
+	if x != nil {
+		return x.MaxLiquidationVolume
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetWeightedAvgLongPrice() float64 {
+	if x != nil {
+		return x.WeightedAvgLongPrice
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetWeightedAvgShortPrice() float64 {
+	if x != nil {
+		return x.WeightedAvgShortPrice
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetSignificantLevels() int64 {
+	if x != nil {
+		return x.SignificantLevels
+	}
+	return 0
+}
+
+func (x *HeatmapSummary) GetCriticalZones() []*CriticalZone {
+	if x != nil {
+		return x.CriticalZones
+	}
+	return nil
+}
+
+type HeatmapData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol       string                `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Exchange     string                `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`    // Lowercase exchange name, e.g. "binance"
+	Timestamp    int64                 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix millis
+	Interval     Interval              `protobuf:"varint,4,opt,name=interval,proto3,enum=gort.models.v1.Interval" json:"interval,omitempty"`
+	CurrentPrice float64               `protobuf:"fixed64,5,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	Levels       []*LiquidationLevel   `protobuf:"bytes,6,rep,name=levels,proto3" json:"levels,omitempty"`
+	Clusters     []*LiquidationCluster `protobuf:"bytes,7,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	Summary      *HeatmapSummary       `protobuf:"bytes,8,opt,name=summary,proto3" json:"summary,omitempty"`
+}
+
+func (x *HeatmapData) Reset() {
+	*x = HeatmapData{}
+	mi := &file_models_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapData) ProtoMessage() {}
+
+func (x *HeatmapData) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapData.ProtoReflect.Descriptor instead.
+func (*HeatmapData) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HeatmapData) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *HeatmapData) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *HeatmapData) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HeatmapData) GetInterval() Interval {
+	if x != nil {
+		return x.Interval
+	}
+	return Interval_INTERVAL_UNSPECIFIED
+}
+
+func (x *HeatmapData) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *HeatmapData) GetLevels() []*LiquidationLevel {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *HeatmapData) GetClusters() []*LiquidationCluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+func (x *HeatmapData) GetSummary() *HeatmapSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+var File_models_proto protoreflect.FileDescriptor
+
+var file_models_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e,
+	0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x81,
+	0x04, 0x0a, 0x10, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x76, 0x67, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x61, 0x76, 0x67, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x71, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x51, 0x74, 0x79, 0x12, 0x21, 0x0a,
+	0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x28, 0x0a, 0x10, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x61, 0x64, 0x65, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x54, 0x72, 0x61, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x22, 0x84, 0x03, 0x0a, 0x0e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x72, 0x6b, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x61, 0x72,
+	0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x75, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x66,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x70,
+	0x65, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0c, 0x6f, 0x70, 0x65, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x5f, 0x75, 0x73, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x6e,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x55, 0x73, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x32, 0x34, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x75,
+	0x72, 0x6e, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x32, 0x34, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x74, 0x75, 0x72, 0x6e, 0x6f, 0x76, 0x65, 0x72, 0x32, 0x34, 0x68, 0x12, 0x2a, 0x0a,
+	0x11, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x46, 0x75,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x97, 0x04, 0x0a, 0x10, 0x4c, 0x69,
+	0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73,
+	0x68, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x79, 0x12, 0x2d, 0x0a, 0x12, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79,
+	0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x70, 0x72, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x77, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x67, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x6f,
+	0x6c, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6f, 0x69, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6f, 0x69, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x63, 0x74,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x50, 0x63, 0x74, 0x22, 0xa3, 0x02, 0x0a, 0x12, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x26,
+	0x0a, 0x0f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x63, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x38, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x70, 0x65, 0x61,
+	0x6b, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x0c, 0x43, 0x72,
+	0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x22, 0xd0, 0x03, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x17, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c,
+	0x6f, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x6f, 0x6e,
+	0x67, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38, 0x0a,
+	0x18, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x4c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x6d,
+	0x61, 0x78, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x6d, 0x61, 0x78,
+	0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x12, 0x35, 0x0a, 0x17, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x76,
+	0x67, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x14, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x41, 0x76, 0x67, 0x4c,
+	0x6f, 0x6e, 0x67, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x76, 0x67, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x65, 0x64, 0x41, 0x76, 0x67, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x66, 0x69, 0x63, 0x61, 0x6e, 0x74,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x73,
+	0x69, 0x67, 0x6e, 0x69, 0x66, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x12, 0x43, 0x0a, 0x0e, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x7a, 0x6f, 0x6e,
+	0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x74, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x69, 0x74, 0x69, 0x63,
+	0x61, 0x6c, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x0d, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c,
+	0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0xee, 0x02, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61,
+	0x70, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x34, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x67, 0x6f, 0x72, 0x74,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x3e, 0x0a, 0x08,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x52, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x07,
+	0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x67, 0x6f, 0x72, 0x74, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2a, 0x58, 0x0a, 0x04, 0x53, 0x69, 0x64, 0x65, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x4c, 0x4f, 0x4e,
+	0x47, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x53, 0x48, 0x4f, 0x52,
+	0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x42, 0x55, 0x59, 0x10,
+	0x03, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x4c, 0x4c, 0x10, 0x04,
+	0x2a, 0x72, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x16, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x49, 0x51, 0x55, 0x49, 0x44, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x41, 0x44, 0x4c, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x41, 0x4e, 0x4b, 0x52, 0x55, 0x50, 0x54,
+	0x43, 0x59, 0x10, 0x03, 0x2a, 0x62, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x49, 0x4e, 0x45, 0x41, 0x52, 0x10, 0x01, 0x12, 0x19, 0x0a,
+	0x15, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49,
+	0x4e, 0x56, 0x45, 0x52, 0x53, 0x45, 0x10, 0x02, 0x2a, 0xad, 0x01, 0x0a, 0x08, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41,
+	0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x31, 0x53, 0x10, 0x01,
+	0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x31, 0x4d, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x35, 0x4d,
+	0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x31,
+	0x35, 0x4d, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41, 0x4c,
+	0x5f, 0x31, 0x48, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41,
+	0x4c, 0x5f, 0x34, 0x48, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56,
+	0x41, 0x4c, 0x5f, 0x31, 0x44, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52,
+	0x56, 0x41, 0x4c, 0x5f, 0x31, 0x57, 0x10, 0x08, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6f, 0x68, 0x75, 0x6e, 0x6e, 0x2f, 0x67, 0x6f,
+	0x72, 0x74, 0x2d, 0x74, 0x72, 0x61, 0x64, 0x65, 0x2d, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x70, 0x62, 0x3b, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_models_proto_rawDescOnce sync.Once
+	file_models_proto_rawDescData = file_models_proto_rawDesc
+)
+
+func file_models_proto_rawDescGZIP() []byte {
+	file_models_proto_rawDescOnce.Do(func() {
+		file_models_proto_rawDescData = protoimpl.X.CompressGZIP(file_models_proto_rawDescData)
+	})
+	return file_models_proto_rawDescData
+}
+
+var file_models_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_models_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_models_proto_goTypes = []any{
+	(Side)(0),                  // 0: gort.models.v1.Side
+	(OrderType)(0),             // 1: gort.models.v1.OrderType
+	(ContractType)(0),          // 2: gort.models.v1.ContractType
+	(Interval)(0),              // 3: gort.models.v1.Interval
+	(*LiquidationEvent)(nil),   // 4: gort.models.v1.LiquidationEvent
+	(*MarketSnapshot)(nil),     // 5: gort.models.v1.MarketSnapshot
+	(*LiquidationLevel)(nil),   // 6: gort.models.v1.LiquidationLevel
+	(*LiquidationCluster)(nil), // 7: gort.models.v1.LiquidationCluster
+	(*CriticalZone)(nil),       // 8: gort.models.v1.CriticalZone
+	(*HeatmapSummary)(nil),     // 9: gort.models.v1.HeatmapSummary
+	(*HeatmapData)(nil),        // 10: gort.models.v1.HeatmapData
+}
+var file_models_proto_depIdxs = []int32{
+	0, // 0: gort.models.v1.LiquidationEvent.side:type_name -> gort.models.v1.Side
+	1, // 1: gort.models.v1.LiquidationEvent.order_type:type_name -> gort.models.v1.OrderType
+	2, // 2: gort.models.v1.LiquidationEvent.contract_type:type_name -> gort.models.v1.ContractType
+	6, // 3: gort.models.v1.LiquidationCluster.levels:type_name -> gort.models.v1.LiquidationLevel
+	8, // 4: gort.models.v1.HeatmapSummary.critical_zones:type_name -> gort.models.v1.CriticalZone
+	3, // 5: gort.models.v1.HeatmapData.interval:type_name -> gort.models.v1.Interval
+	6, // 6: gort.models.v1.HeatmapData.levels:type_name -> gort.models.v1.LiquidationLevel
+	7, // 7: gort.models.v1.HeatmapData.clusters:type_name -> gort.models.v1.LiquidationCluster
+	9, // 8: gort.models.v1.HeatmapData.summary:type_name -> gort.models.v1.HeatmapSummary
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_models_proto_init() }
+func file_models_proto_init() {
+	if File_models_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_models_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_models_proto_goTypes,
+		DependencyIndexes: file_models_proto_depIdxs,
+		EnumInfos:         file_models_proto_enumTypes,
+		MessageInfos:      file_models_proto_msgTypes,
+	}.Build()
+	File_models_proto = out.File
+	file_models_proto_rawDesc = nil
+	file_models_proto_goTypes = nil
+	file_models_proto_depIdxs = nil
+}